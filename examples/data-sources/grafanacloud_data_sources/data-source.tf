@@ -0,0 +1,4 @@
+data "grafanacloud_data_sources" "prometheus" {
+  stack = "demo"
+  type  = "prometheus"
+}
@@ -0,0 +1,3 @@
+data "grafanacloud_hosted_metrics" "demo" {
+  stack = "demo"
+}
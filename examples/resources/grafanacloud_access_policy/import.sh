@@ -0,0 +1,2 @@
+# Access policies are imported by `<region>/<policy ID>`
+terraform import grafanacloud_access_policy.metrics_publisher eu/1234
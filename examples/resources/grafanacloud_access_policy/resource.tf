@@ -0,0 +1,14 @@
+resource "grafanacloud_access_policy" "metrics_publisher" {
+  name   = "metrics-publisher"
+  region = "eu"
+  scopes = ["metrics:write"]
+
+  realm {
+    type       = "stack"
+    identifier = grafanacloud_stack.demo.id
+
+    label_policy {
+      selector = "{namespace=\"default\"}"
+    }
+  }
+}
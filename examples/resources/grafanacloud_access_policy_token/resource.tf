@@ -0,0 +1,8 @@
+resource "grafanacloud_access_policy_token" "ci" {
+  name             = "ci"
+  region           = grafanacloud_access_policy.metrics_publisher.region
+  access_policy_id = grafanacloud_access_policy.metrics_publisher.policy_id
+
+  # Expired tokens are recreated on the next apply
+  seconds_to_live = 2592000
+}
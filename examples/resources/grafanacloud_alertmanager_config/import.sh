@@ -0,0 +1,5 @@
+# The Alertmanager configuration is imported by `<stack slug>`
+terraform import grafanacloud_alertmanager_config.demo demo
+
+# Alertmanager configurations of stacks in other organisations than the one of the provider are imported with the organisation prepended
+terraform import grafanacloud_alertmanager_config.demo other-org/demo
@@ -0,0 +1,7 @@
+# Contact points are imported by `<stack slug>/<contact point name>`. Secure settings can't be
+# read from Grafana, so they need to be applied again after importing.
+terraform import grafanacloud_contact_point.team_a demo/team-a
+
+# Contact points of stacks in other organisations than the one of the provider are imported with the organisation prepended.
+# Names containing slashes need the organisation prepended even in the organisation of the provider
+terraform import grafanacloud_contact_point.team_a other-org/demo/team-a
@@ -0,0 +1,18 @@
+resource "grafanacloud_contact_point" "team_a" {
+  stack = "demo"
+  name  = "team-a"
+
+  email {
+    addresses = ["team-a@example.com"]
+  }
+
+  slack {
+    url       = var.slack_webhook_url
+    recipient = "#team-a-alerts"
+  }
+
+  pagerduty {
+    integration_key = var.pagerduty_integration_key
+    severity        = "critical"
+  }
+}
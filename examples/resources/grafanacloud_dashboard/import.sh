@@ -0,0 +1,5 @@
+# Dashboards are imported by `<stack slug>/<dashboard UID>`
+terraform import grafanacloud_dashboard.overview demo/overview
+
+# Dashboards of stacks in other organisations than the one of the provider are imported with the organisation prepended
+terraform import grafanacloud_dashboard.overview other-org/demo/overview
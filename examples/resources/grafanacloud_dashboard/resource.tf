@@ -0,0 +1,8 @@
+resource "grafanacloud_dashboard" "overview" {
+  stack = "demo"
+  config_json = jsonencode({
+    uid    = "overview"
+    title  = "Overview"
+    panels = []
+  })
+}
@@ -0,0 +1,5 @@
+# Data sources are imported by `<stack slug>/<data source UID>`. Secure JSON data can't be read back, so `secure_json_data` will be empty
+terraform import grafanacloud_data_source.prometheus demo/prometheus
+
+# Data sources of stacks in other organisations than the one of the provider are imported with the organisation prepended
+terraform import grafanacloud_data_source.prometheus other-org/demo/prometheus
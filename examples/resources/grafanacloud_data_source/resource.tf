@@ -0,0 +1,19 @@
+resource "grafanacloud_data_source" "prometheus" {
+  stack = "demo"
+  uid   = "prometheus"
+  name  = "Prometheus"
+  type  = "prometheus"
+  url   = "https://prometheus.example.com"
+
+  basic_auth_enabled  = true
+  basic_auth_username = "admin"
+
+  json_data = jsonencode({
+    httpMethod   = "POST"
+    timeInterval = "30s"
+  })
+
+  secure_json_data = {
+    basicAuthPassword = "secret"
+  }
+}
@@ -0,0 +1,5 @@
+# Folders are imported by `<stack slug>/<folder UID>`
+terraform import grafanacloud_folder.team_a demo/team-a
+
+# Folders of stacks in other organisations than the one of the provider are imported with the organisation prepended
+terraform import grafanacloud_folder.team_a other-org/demo/team-a
@@ -0,0 +1,5 @@
+resource "grafanacloud_folder" "team_a" {
+  stack = "demo"
+  uid   = "team-a"
+  title = "Team A"
+}
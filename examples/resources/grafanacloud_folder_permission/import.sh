@@ -0,0 +1,5 @@
+# Folder permissions are imported by `<stack slug>/<folder UID>`
+terraform import grafanacloud_folder_permission.team_a demo/team-a
+
+# Folder permissions of stacks in other organisations than the one of the provider are imported with the organisation prepended
+terraform import grafanacloud_folder_permission.team_a other-org/demo/team-a
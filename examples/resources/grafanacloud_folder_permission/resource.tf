@@ -0,0 +1,20 @@
+resource "grafanacloud_folder" "team_a" {
+  stack = "demo"
+  uid   = "team-a"
+  title = "Team A"
+}
+
+resource "grafanacloud_folder_permission" "team_a" {
+  stack      = grafanacloud_folder.team_a.stack
+  folder_uid = grafanacloud_folder.team_a.uid
+
+  permission {
+    role       = "Viewer"
+    permission = "View"
+  }
+
+  permission {
+    team_id    = 1
+    permission = "Edit"
+  }
+}
@@ -0,0 +1,8 @@
+# Grafana API keys are imported by `<stack slug>/<API key ID>`. The key value can't be read back, so `key` will be empty
+terraform import grafanacloud_grafana_api_key.api_client demo/1
+
+# API keys migrated to a service account are imported by `<stack slug>/<API key ID>/<service account ID>`
+terraform import grafanacloud_grafana_api_key.api_client demo/1/2
+
+# API keys of stacks in other organisations than the one of the provider are imported with the organisation prepended
+terraform import grafanacloud_grafana_api_key.api_client other-org/demo/1
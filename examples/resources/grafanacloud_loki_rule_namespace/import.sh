@@ -0,0 +1,6 @@
+# Rule namespaces are imported by `<stack slug>/<namespace name>`
+terraform import grafanacloud_loki_rule_namespace.api demo/api
+
+# Rule namespaces of stacks in other organisations than the one of the provider are imported with the organisation prepended.
+# Names containing slashes need the organisation prepended even in the organisation of the provider
+terraform import grafanacloud_loki_rule_namespace.api other-org/demo/api
@@ -0,0 +1,18 @@
+resource "grafanacloud_loki_rule_namespace" "api" {
+  stack = "demo"
+  name  = "api"
+
+  content = <<-EOT
+    groups:
+      - name: api
+        interval: 1m
+        rules:
+          - record: job:log_errors:rate5m
+            expr: sum by (job) (rate({job="api"} |= "error" [5m]))
+          - alert: HighLogErrorRate
+            expr: sum by (job) (rate({job="api"} |= "error" [5m])) > 10
+            for: 10m
+            labels:
+              severity: critical
+  EOT
+}
@@ -0,0 +1,6 @@
+# Mute timings are imported by `<stack slug>/<mute timing name>`
+terraform import grafanacloud_mute_timing.weekends demo/weekends
+
+# Mute timings of stacks in other organisations than the one of the provider are imported with the organisation prepended.
+# Names containing slashes need the organisation prepended even in the organisation of the provider
+terraform import grafanacloud_mute_timing.weekends other-org/demo/weekends
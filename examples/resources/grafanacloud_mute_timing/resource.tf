@@ -0,0 +1,9 @@
+resource "grafanacloud_mute_timing" "weekends" {
+  stack = "demo"
+  name  = "weekends"
+
+  interval {
+    weekdays = ["saturday", "sunday"]
+    location = "Europe/London"
+  }
+}
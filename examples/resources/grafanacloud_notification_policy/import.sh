@@ -0,0 +1,5 @@
+# The notification policy is imported by `<stack slug>`
+terraform import grafanacloud_notification_policy.demo demo
+
+# Notification policies of stacks in other organisations than the one of the provider are imported with the organisation prepended
+terraform import grafanacloud_notification_policy.demo other-org/demo
@@ -0,0 +1,17 @@
+resource "grafanacloud_notification_policy" "demo" {
+  stack           = "demo"
+  contact_point   = grafanacloud_contact_point.team_a.name
+  group_by        = ["grafana_folder", "alertname"]
+  repeat_interval = "4h"
+
+  policy {
+    contact_point = grafanacloud_contact_point.team_b.name
+    mute_timings  = [grafanacloud_mute_timing.weekends.name]
+
+    matcher {
+      label = "team"
+      match = "="
+      value = "team-b"
+    }
+  }
+}
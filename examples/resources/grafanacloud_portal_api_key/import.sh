@@ -0,0 +1,5 @@
+# Portal API keys are imported by their name. The key value can't be read back, so `key` will be empty
+terraform import grafanacloud_portal_api_key.prometheus_remote_write prometheus_remote_write
+
+# Portal API keys of other organisations than the one of the provider are imported with the organisation prepended
+terraform import grafanacloud_portal_api_key.prometheus_remote_write other-org/prometheus_remote_write
@@ -0,0 +1,18 @@
+resource "grafanacloud_prometheus_rule_namespace" "api" {
+  stack = "demo"
+  name  = "api"
+
+  content = <<-EOT
+    groups:
+      - name: api
+        interval: 1m
+        rules:
+          - record: job:http_requests:rate5m
+            expr: sum by (job) (rate(http_requests_total{job="api"}[5m]))
+          - alert: HighErrorRate
+            expr: sum(rate(http_requests_total{job="api", code=~"5.."}[5m])) / job:http_requests:rate5m > 0.05
+            for: 10m
+            labels:
+              severity: critical
+  EOT
+}
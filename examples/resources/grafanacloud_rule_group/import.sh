@@ -0,0 +1,6 @@
+# Rule groups are imported by `<stack slug>/<folder UID>/<group name>`
+terraform import grafanacloud_rule_group.availability demo/team-a/availability
+
+# Rule groups of stacks in other organisations than the one of the provider are imported with the organisation prepended.
+# Names containing slashes need the organisation prepended even in the organisation of the provider
+terraform import grafanacloud_rule_group.availability other-org/demo/team-a/availability
@@ -0,0 +1,62 @@
+resource "grafanacloud_folder" "team_a" {
+  stack = "demo"
+  uid   = "team-a"
+  title = "Team A"
+}
+
+resource "grafanacloud_rule_group" "availability" {
+  stack            = "demo"
+  folder_uid       = grafanacloud_folder.team_a.uid
+  name             = "availability"
+  interval_seconds = 60
+
+  rule {
+    name      = "Instance down"
+    condition = "B"
+    for       = "5m"
+
+    labels = {
+      severity = "critical"
+      team     = "team-a"
+    }
+
+    annotations = {
+      summary = "{{ $labels.instance }} is down"
+    }
+
+    data {
+      ref_id         = "A"
+      datasource_uid = "grafanacloud-prom"
+
+      relative_time_range {
+        from = 600
+        to   = 0
+      }
+
+      model = jsonencode({
+        expr = "up{job=\"api\"} == 0"
+      })
+    }
+
+    data {
+      ref_id         = "B"
+      datasource_uid = "__expr__"
+
+      relative_time_range {
+        from = 0
+        to   = 0
+      }
+
+      model = jsonencode({
+        type       = "threshold"
+        expression = "A"
+        conditions = [{
+          evaluator = {
+            type   = "gt"
+            params = [0]
+          }
+        }]
+      })
+    }
+  }
+}
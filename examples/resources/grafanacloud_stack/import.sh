@@ -0,0 +1,5 @@
+# Stacks are imported by their slug
+terraform import grafanacloud_stack.demo demo
+
+# Stacks of other organisations than the one of the provider are imported by organisation and slug
+terraform import grafanacloud_stack.demo other-org/demo
@@ -0,0 +1,10 @@
+resource "grafanacloud_stack" "demo" {
+  name        = "demo"
+  slug        = "demo"
+  region_slug = "eu"
+  description = "Stack for the demo team"
+
+  labels = {
+    team = "demo"
+  }
+}
@@ -0,0 +1,2 @@
+# Stack plugins are imported by `<stack slug>/<plugin slug>`
+terraform import grafanacloud_stack_plugin.clock_panel demo/grafana-clock-panel
@@ -0,0 +1,5 @@
+resource "grafanacloud_stack_plugin" "clock_panel" {
+  stack   = "demo"
+  slug    = "grafana-clock-panel"
+  version = "1.3.0"
+}
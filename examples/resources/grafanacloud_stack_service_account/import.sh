@@ -0,0 +1,5 @@
+# Service accounts are imported by `<stack slug>/<service account ID>`
+terraform import grafanacloud_stack_service_account.api_client demo/1
+
+# Service accounts of stacks in other organisations than the one of the provider are imported with the organisation prepended
+terraform import grafanacloud_stack_service_account.api_client other-org/demo/1
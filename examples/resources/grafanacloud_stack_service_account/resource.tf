@@ -0,0 +1,5 @@
+resource "grafanacloud_stack_service_account" "api_client" {
+  name  = "api_client"
+  role  = "Editor"
+  stack = "demo"
+}
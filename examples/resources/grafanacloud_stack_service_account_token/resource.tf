@@ -0,0 +1,12 @@
+resource "grafanacloud_stack_service_account" "api_client" {
+  name  = "api_client"
+  role  = "Editor"
+  stack = "demo"
+}
+
+resource "grafanacloud_stack_service_account_token" "api_client" {
+  name               = "api_client"
+  stack              = grafanacloud_stack_service_account.api_client.stack
+  service_account_id = grafanacloud_stack_service_account.api_client.service_account_id
+  seconds_to_live    = 86400
+}
@@ -8,4 +8,6 @@ require (
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.9.0
 	github.com/relvacode/iso8601 v1.1.0
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/time v0.0.0-20191024005414-555d28b269f0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,108 @@
+package grafanacloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+func dataSourceDataSources() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads all data sources of a Grafana Cloud stack, including the built-in `grafanacloud-*` data sources for its hosted instances.",
+		ReadContext: dataSourceDataSourcesRead,
+		Schema: map[string]*schema.Schema{
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Slug of the stack to read the data sources of.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only read data sources of this type, e.g. `prometheus`.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only read the data source with this name.",
+			},
+			"data_sources": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Numeric ID of the data source.",
+						},
+						"uid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "UID of the data source.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the data source.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the data source.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URL of the data source.",
+						},
+						"basic_auth_user": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "User name for basic authentication, if enabled.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDataSourcesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+	stack := d.Get("stack").(string)
+
+	resp, err := p.Client.ListDatasources(ctx, stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filtered := resp.Filter(d.Get("type").(string), d.Get("name").(string))
+	if err := d.Set("data_sources", datasourceListToSchema(filtered)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(stack)
+
+	return diags
+}
+
+func datasourceListToSchema(datasources *portal.ListDatasourcesOutput) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+
+	for _, ds := range datasources.Items {
+		result = append(result, map[string]interface{}{
+			"id":              ds.ID,
+			"uid":             ds.UID,
+			"name":            ds.Name,
+			"type":            ds.Type,
+			"url":             ds.URL,
+			"basic_auth_user": ds.BasicAuthUser,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,99 @@
+package grafanacloud_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDataSources_Basic(t *testing.T) {
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDataSourcesConfig(name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafanacloud_data_sources.test", "id", name+"slug"),
+					resource.TestCheckResourceAttr("data.grafanacloud_data_sources.test", "data_sources.#", "5"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.grafanacloud_data_sources.test", "data_sources.*", map[string]string{
+						"name": "grafanacloud-" + name + "slug-prom",
+						"type": "prometheus",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.grafanacloud_data_sources.test", "data_sources.*", map[string]string{
+						"uid":  name,
+						"name": name,
+						"type": "elasticsearch",
+						"url":  "https://elasticsearch.example.com",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceDataSources_Filter(t *testing.T) {
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDataSourcesConfig(name, `type = "prometheus"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafanacloud_data_sources.test", "data_sources.#", "1"),
+					resource.TestCheckResourceAttr("data.grafanacloud_data_sources.test", "data_sources.0.name", "grafanacloud-"+name+"slug-prom"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_data_sources.test", "data_sources.0.url"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_data_sources.test", "data_sources.0.id"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_data_sources.test", "data_sources.0.uid"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_data_sources.test", "data_sources.0.basic_auth_user"),
+				),
+			},
+			{
+				Config: testAccDataSourceDataSourcesConfig(name, fmt.Sprintf(`name = "%s"`, name)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafanacloud_data_sources.test", "data_sources.#", "1"),
+					resource.TestCheckResourceAttr("data.grafanacloud_data_sources.test", "data_sources.0.type", "elasticsearch"),
+				),
+			},
+			{
+				Config: testAccDataSourceDataSourcesConfig(name, `type = "graphite"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafanacloud_data_sources.test", "data_sources.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDataSourcesConfig(name, filter string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+
+resource "grafanacloud_data_source" "test" {
+  stack = grafanacloud_stack.test.slug
+  uid   = "%s"
+  name  = "%s"
+  type  = "elasticsearch"
+  url   = "https://elasticsearch.example.com"
+}
+
+data "grafanacloud_data_sources" "test" {
+  stack = grafanacloud_stack.test.slug
+  %s
+
+  depends_on = [
+    grafanacloud_data_source.test
+  ]
+}
+`, name, name, name, name, filter)
+}
@@ -0,0 +1,119 @@
+package grafanacloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+// Reads a single hosted instance of a stack. The getter returns the instance for the given stack, or
+// nil if the stack has no such instance.
+type hostedInstanceGetter func(ctx context.Context, c *portal.Client, stack *portal.Stack) (*portal.HostedInstance, error)
+
+func hostedInstanceSchema(kind string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("ID of the %s instance.", kind),
+		},
+		"stack": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: fmt.Sprintf("Slug of the stack the %s instance belongs to.", kind),
+		},
+		"organisation": dataSourceOrganisationSchema(),
+		"user_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: fmt.Sprintf("User ID of the %s instance, used as the user name when sending data to it.", kind),
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Name of the %s instance.", kind),
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Type of the %s instance.", kind),
+		},
+		"url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Base URL of the %s instance.", kind),
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Status of the %s instance.", kind),
+		},
+		"cluster_slug": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Slug of the cluster the %s instance is running in.", kind),
+		},
+	}
+}
+
+func hostedInstanceRead(kind string, get hostedInstanceGetter) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		var diags diag.Diagnostics
+		p := m.(*Provider)
+
+		if err := d.Set("organisation", p.organisation(d)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		slug := d.Get("stack").(string)
+
+		stack, err := p.Client.GetStack(ctx, p.organisation(d), slug)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if stack == nil {
+			return diag.Errorf("Couldn't find stack with slug `%s`", slug)
+		}
+
+		instance, err := get(ctx, p.Client, stack)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if instance == nil {
+			return diag.Errorf("Stack `%s` has no %s instance", slug, kind)
+		}
+
+		d.SetId(fmt.Sprint(instance.ID))
+
+		if err := d.Set("user_id", instance.ID); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("name", instance.Name); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("type", instance.Type); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("url", instance.URL); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("status", instance.Status); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("cluster_slug", instance.ClusterSlug); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diags
+	}
+}
@@ -0,0 +1,66 @@
+package grafanacloud_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceHostedInstance_Basic(t *testing.T) {
+	var tests = []struct {
+		dataSource string
+		stackAttr  string
+		typ        string
+	}{
+		{"grafanacloud_hosted_metrics", "prometheus", "prometheus"},
+		{"grafanacloud_hosted_logs", "logs", "logs"},
+		{"grafanacloud_hosted_traces", "traces", "traces"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dataSource, func(t *testing.T) {
+			resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+			dataSourceName := "data." + tt.dataSource + ".test"
+
+			resource.Test(t, resource.TestCase{
+				Providers:    testAccProviders,
+				CheckDestroy: testAccCheckStackDestroy,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccDataSourceHostedInstanceConfig(resourceName, tt.dataSource),
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+							resource.TestCheckResourceAttrPair(dataSourceName, "user_id", "data.grafanacloud_stack.test", tt.stackAttr+"_user_id"),
+							resource.TestCheckResourceAttr(dataSourceName, "stack", resourceName+"slug"),
+							resource.TestCheckResourceAttrPair(dataSourceName, "url", "data.grafanacloud_stack.test", tt.stackAttr+"_url"),
+							resource.TestCheckResourceAttr(dataSourceName, "type", tt.typ),
+							resource.TestCheckResourceAttr(dataSourceName, "status", "active"),
+							resource.TestCheckResourceAttrSet(dataSourceName, "name"),
+							resource.TestCheckResourceAttrSet(dataSourceName, "cluster_slug"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccDataSourceHostedInstanceConfig(resourceName, dataSource string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+
+data "grafanacloud_stack" "test" {
+  slug = grafanacloud_stack.test.slug
+}
+
+data "%s" "test" {
+  stack = grafanacloud_stack.test.slug
+}
+`, resourceName, resourceName, dataSource)
+}
@@ -0,0 +1,24 @@
+package grafanacloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+func dataSourceHostedLogs() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the hosted Loki instance of a Grafana Cloud stack, e.g. in order to configure agents sending logs to it.",
+		ReadContext: hostedInstanceRead("Loki", getHostedLogsInstance),
+		Schema:      hostedInstanceSchema("Loki"),
+	}
+}
+
+func getHostedLogsInstance(ctx context.Context, c *portal.Client, stack *portal.Stack) (*portal.HostedInstance, error) {
+	if stack.HlInstanceID == 0 {
+		return nil, nil
+	}
+
+	return c.GetHostedLogsInstance(ctx, stack.HlInstanceID)
+}
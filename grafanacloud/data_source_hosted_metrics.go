@@ -0,0 +1,24 @@
+package grafanacloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+func dataSourceHostedMetrics() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the hosted Prometheus instance of a Grafana Cloud stack, e.g. in order to configure agents sending metrics to it.",
+		ReadContext: hostedInstanceRead("Prometheus", getHostedMetricsInstance),
+		Schema:      hostedInstanceSchema("Prometheus"),
+	}
+}
+
+func getHostedMetricsInstance(ctx context.Context, c *portal.Client, stack *portal.Stack) (*portal.HostedInstance, error) {
+	if stack.HmInstancePromID == 0 {
+		return nil, nil
+	}
+
+	return c.GetHostedMetricsInstance(ctx, stack.HmInstancePromID)
+}
@@ -0,0 +1,24 @@
+package grafanacloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+func dataSourceHostedTraces() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the hosted Tempo instance of a Grafana Cloud stack, e.g. in order to configure agents sending traces to it.",
+		ReadContext: hostedInstanceRead("Tempo", getHostedTracesInstance),
+		Schema:      hostedInstanceSchema("Tempo"),
+	}
+}
+
+func getHostedTracesInstance(ctx context.Context, c *portal.Client, stack *portal.Stack) (*portal.HostedInstance, error) {
+	if stack.HtInstanceID == 0 {
+		return nil, nil
+	}
+
+	return c.GetHostedTracesInstance(ctx, stack.HtInstanceID)
+}
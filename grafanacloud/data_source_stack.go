@@ -12,6 +12,7 @@ func dataSourceStack() *schema.Resource {
 	s := baseStackSchema()
 	s["slug"].Required = true
 	s["slug"].Computed = false
+	s["organisation"] = dataSourceOrganisationSchema()
 
 	return &schema.Resource{
 		Description: "Reads a single Grafana Cloud stack from the organisation by the given name.",
@@ -22,9 +23,14 @@ func dataSourceStack() *schema.Resource {
 func dataSourceStackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	slug := d.Get("slug").(string)
 
-	stackList, err := listStacks(ctx, p)
+	stackList, err := listStacks(ctx, p, p.organisation(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -44,6 +50,18 @@ func dataSourceStackRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("region_slug", stack.RegionSlug); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("description", stack.Description); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("labels", stack.Labels); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("prometheus_url", stack.HmInstancePromURL); err != nil {
 		return diag.FromErr(err)
 	}
@@ -52,6 +70,38 @@ func dataSourceStackRead(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("graphite_url", stack.HmInstanceGraphiteURL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("graphite_user_id", stack.HmInstanceGraphiteID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("logs_url", stack.HlInstanceURL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("logs_user_id", stack.HlInstanceID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("traces_url", stack.HtInstanceURL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("traces_user_id", stack.HtInstanceID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("profiles_url", stack.HpInstanceURL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("profiles_user_id", stack.HpInstanceID); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("alertmanager_url", stack.AmInstanceURL); err != nil {
 		return diag.FromErr(err)
 	}
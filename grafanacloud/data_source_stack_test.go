@@ -2,6 +2,7 @@ package grafanacloud_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -21,9 +22,21 @@ func TestAccDataSourceStack_Basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "id"),
 					resource.TestCheckResourceAttr("data.grafanacloud_stack.test", "name", resourceName),
 					resource.TestCheckResourceAttr("data.grafanacloud_stack.test", "slug", resourceName+"slug"),
+					resource.TestCheckResourceAttr("data.grafanacloud_stack.test", "region_slug", "eu"),
+					resource.TestCheckResourceAttr("data.grafanacloud_stack.test", "description", "Managed by Terraform"),
+					resource.TestCheckResourceAttr("data.grafanacloud_stack.test", "labels.team", "observability"),
 					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "prometheus_url"),
 					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "prometheus_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "graphite_url"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "graphite_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "logs_url"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "logs_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "traces_url"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "traces_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "profiles_url"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "profiles_user_id"),
 					resource.TestCheckResourceAttrSet("data.grafanacloud_stack.test", "alertmanager_user_id"),
+					resource.TestMatchResourceAttr("data.grafanacloud_stack.test", "alertmanager_url", regexp.MustCompile("/alertmanager/"+resourceName+"slug$")),
 				),
 			},
 		},
@@ -33,8 +46,16 @@ func TestAccDataSourceStack_Basic(t *testing.T) {
 func testAccDataSourceStackConfig(resourceName string) string {
 	return fmt.Sprintf(`
 resource "grafanacloud_stack" "test" {
-  name = "%s"
-  slug = "%sslug"
+  name        = "%s"
+  slug        = "%sslug"
+  region_slug = "eu"
+  description = "Managed by Terraform"
+
+  labels = {
+    team = "observability"
+  }
+
+  delete_protection = false
 }
 
 data "grafanacloud_stack" "test" {
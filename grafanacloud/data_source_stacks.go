@@ -2,7 +2,6 @@ package grafanacloud
 
 import (
 	"context"
-	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -29,6 +28,7 @@ func dataSourceStacks() *schema.Resource {
 					Schema: s,
 				},
 			},
+			"organisation": dataSourceOrganisationSchema(),
 		},
 	}
 }
@@ -37,7 +37,11 @@ func dataSourceStacksRead(ctx context.Context, d *schema.ResourceData, m interfa
 	var diags diag.Diagnostics
 	p := m.(*Provider)
 
-	stacks, err := listStacks(ctx, p)
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stacks, err := listStacks(ctx, p, p.organisation(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -60,8 +64,19 @@ func stackListToSchema(stackList *portal.ListStacksOutput) []map[string]interfac
 			"id":                   stack.ID,
 			"name":                 stack.Name,
 			"slug":                 stack.Slug,
+			"region_slug":          stack.RegionSlug,
+			"description":          stack.Description,
+			"labels":               stack.Labels,
 			"prometheus_url":       stack.HmInstancePromURL,
 			"prometheus_user_id":   stack.HmInstancePromID,
+			"graphite_url":         stack.HmInstanceGraphiteURL,
+			"graphite_user_id":     stack.HmInstanceGraphiteID,
+			"logs_url":             stack.HlInstanceURL,
+			"logs_user_id":         stack.HlInstanceID,
+			"traces_url":           stack.HtInstanceURL,
+			"traces_user_id":       stack.HtInstanceID,
+			"profiles_url":         stack.HpInstanceURL,
+			"profiles_user_id":     stack.HpInstanceID,
 			"alertmanager_url":     stack.AmInstanceURL,
 			"alertmanager_user_id": stack.AmInstanceID,
 		})
@@ -70,15 +85,15 @@ func stackListToSchema(stackList *portal.ListStacksOutput) []map[string]interfac
 	return result
 }
 
-func listStacks(ctx context.Context, p *Provider) (*portal.ListStacksOutput, error) {
-	resp, err := p.Client.ListStacks(ctx, p.Organisation)
+func listStacks(ctx context.Context, p *Provider, org string) (*portal.ListStacksOutput, error) {
+	resp, err := p.Client.ListStacks(ctx, org)
 	if err != nil {
 		return nil, err
 	}
 
 	newItems := make([]*portal.Stack, 0)
 	for _, stack := range resp.Items {
-		alertmanager, err := findAlertmanagerDatasource(ctx, p, stack)
+		alertmanager, err := p.Client.FindAlertmanagerDatasource(ctx, stack.Slug)
 		if err != nil {
 			log.Printf("[WARN] couldn't infer Alertmanager URL from Grafana instance: %v", err)
 		}
@@ -94,21 +109,6 @@ func listStacks(ctx context.Context, p *Provider) (*portal.ListStacksOutput, err
 	return resp, nil
 }
 
-func findAlertmanagerDatasource(ctx context.Context, p *Provider, stack *portal.Stack) (*portal.Datasource, error) {
-	ds, err := p.Client.ListDatasources(ctx, stack.Slug)
-	if err != nil {
-		return nil, fmt.Errorf("error while locating Alertmanager instance for stack %s: %v", stack.Slug, err)
-	}
-
-	for _, datasource := range ds.Items {
-		if datasource.IsAlertmanager() {
-			return datasource, nil
-		}
-	}
-
-	return nil, nil
-}
-
 func baseStackSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"id": {
@@ -126,6 +126,22 @@ func baseStackSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Slug name of the stack.",
 		},
+		"region_slug": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Region the stack is deployed to.",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Description of the stack.",
+		},
+		"labels": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Labels attached to the stack.",
+		},
 		"prometheus_url": {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -136,6 +152,46 @@ func baseStackSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "User ID of the Prometheus instance configured for this stack.",
 		},
+		"graphite_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Base URL of the Graphite instance configured for this stack.",
+		},
+		"graphite_user_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "User ID of the Graphite instance configured for this stack.",
+		},
+		"logs_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Base URL of the Loki instance configured for this stack.",
+		},
+		"logs_user_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "User ID of the Loki instance configured for this stack.",
+		},
+		"traces_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Base URL of the Tempo instance configured for this stack.",
+		},
+		"traces_user_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "User ID of the Tempo instance configured for this stack.",
+		},
+		"profiles_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Base URL of the Pyroscope instance configured for this stack.",
+		},
+		"profiles_user_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "User ID of the Pyroscope instance configured for this stack.",
+		},
 		"alertmanager_url": {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -22,6 +22,14 @@ func TestAccDataSourceStacks_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("data.grafanacloud_stacks.test", "stacks.0.slug", name+"slug"),
 					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.prometheus_url"),
 					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.prometheus_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.graphite_url"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.graphite_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.logs_url"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.logs_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.traces_url"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.traces_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.profiles_url"),
+					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.profiles_user_id"),
 					resource.TestCheckResourceAttrSet("data.grafanacloud_stacks.test", "stacks.0.alertmanager_user_id"),
 				),
 			},
@@ -52,6 +60,7 @@ func testAccDataSourceStacksConfig(name string) string {
 resource "grafanacloud_stack" "foo" {
   name = "%s"
   slug = "%sslug"
+  delete_protection = false
 }
 
 data "grafanacloud_stacks" "test" {
@@ -67,11 +76,13 @@ func testAccDataSourceStacksMultipleConfig(name1, name2 string) string {
 resource "grafanacloud_stack" "foo" {
   name = "%s"
   slug = "%sslug"
+  delete_protection = false
 }
 
 resource "grafanacloud_stack" "bar" {
   name = "%s"
   slug = "%sslug"
+  delete_protection = false
 }
 
 data "grafanacloud_stacks" "test" {
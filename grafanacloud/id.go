@@ -0,0 +1,21 @@
+package grafanacloud
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Some resources are only unique within a parent (e.g. a stack or a region), so their
+// Terraform ID is composed of the parent and the resource ID separated by a slash.
+func compositeID(parent, id string) string {
+	return fmt.Sprintf("%s/%s", parent, id)
+}
+
+func splitCompositeID(id, format string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid ID `%s`, expected `%s`", id, format)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -1,6 +1,7 @@
 package grafanacloud_test
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -17,6 +18,9 @@ var (
 
 const (
 	EnvMock = "GRAFANA_CLOUD_MOCK"
+
+	// Another organisation the API key has access to, which the mock provides by default
+	EnvOtherOrganisation = "GRAFANA_CLOUD_OTHER_ORGANISATION"
 )
 
 func TestMain(m *testing.M) {
@@ -30,15 +34,24 @@ func TestMain(m *testing.M) {
 		"grafanacloud": testAccProvider,
 	}
 
-	os.Exit(m.Run())
+	code := m.Run()
+	grafanacloud.Shutdown(context.Background())
+	os.Exit(code)
 }
 
 func startMock() {
 	if os.Getenv(EnvMock) == "1" {
 		org := os.Getenv(grafanacloud.EnvOrganisation)
+		otherOrg := os.Getenv(EnvOtherOrganisation)
+		if otherOrg == "" {
+			otherOrg = "other-org"
+		}
+
 		grafanaCloudMock = mock.NewGrafanaCloud(org).
+			WithOrganisation(otherOrg).
 			Start()
 
 		os.Setenv(grafanacloud.EnvURL, grafanaCloudMock.URL())
+		os.Setenv(EnvOtherOrganisation, otherOrg)
 	}
 }
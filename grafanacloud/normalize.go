@@ -0,0 +1,53 @@
+package grafanacloud
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Normalises a JSON attribute by parsing it and marshalling the result again, so formatting and the order
+// of keys don't result in a diff. Values which fail to parse, or parse to nil, are stored as is, as
+// invalid JSON is caught by validation.
+func normalizeJSON(value interface{}, parse func(string) (interface{}, error)) string {
+	parsed, err := parse(value.(string))
+	if err != nil || parsed == nil {
+		return value.(string)
+	}
+
+	// Keys of maps are sorted when marshalling
+	result, err := json.Marshal(parsed)
+	if err != nil {
+		return value.(string)
+	}
+
+	return string(result)
+}
+
+// Like normalizeJSON, but for YAML attributes.
+func normalizeYAML(value interface{}, parse func(string) (interface{}, error)) string {
+	parsed, err := parse(value.(string))
+	if err != nil || parsed == nil {
+		return value.(string)
+	}
+
+	result, err := marshalYAML(parsed)
+	if err != nil {
+		return value.(string)
+	}
+
+	return result
+}
+
+// Marshals YAML indented by two spaces, like it's usually written by hand.
+func marshalYAML(v interface{}) (string, error) {
+	var result bytes.Buffer
+	encoder := yaml.NewEncoder(&result)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
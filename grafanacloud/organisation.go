@@ -0,0 +1,76 @@
+package grafanacloud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resources and data sources are managed in the organisation of the provider by default, but can
+// override it, so several organisations can be managed without a provider per organisation. Reads
+// always record the organisation, so setting it to the one of the provider doesn't force a new resource.
+func organisationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Organisation to manage the resource in (as slug name). Defaults to the `organisation` of the provider. The API key of the provider must have access to the organisation.",
+	}
+}
+
+// Moving resources to another organisation requires them to be recreated.
+func resourceOrganisationSchema() *schema.Schema {
+	s := organisationSchema()
+	s.ForceNew = true
+
+	return s
+}
+
+func dataSourceOrganisationSchema() *schema.Schema {
+	s := organisationSchema()
+	s.Description = "Organisation to read from (as slug name). Defaults to the `organisation` of the provider. The API key of the provider must have access to the organisation."
+
+	return s
+}
+
+// Returns the organisation the resource or data source is managed in.
+func (p *Provider) organisation(d *schema.ResourceData) string {
+	if org, ok := d.GetOk("organisation"); ok {
+		return org.(string)
+	}
+
+	return p.Organisation
+}
+
+// Imports resources by their ID, which is prefixed by `organisation/` for resources of other organisations
+// than the one of the provider, like stacks. The prefix is recognised by the ID having more than the given
+// number of parts separated by slashes, so names containing slashes can only be imported with the prefix.
+func importWithOrganisation(parts int) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if err := setImportOrganisation(d, strings.Count(d.Id(), "/")+1 > parts); err != nil {
+			return nil, err
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// Moves the organisation prefix of the import ID into `organisation` if there's one.
+func setImportOrganisation(d *schema.ResourceData, prefixed bool) error {
+	if !prefixed {
+		return nil
+	}
+
+	org, id, err := splitCompositeID(d.Id(), "organisation/id")
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("organisation", org); err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return nil
+}
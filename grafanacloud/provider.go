@@ -3,6 +3,10 @@ package grafanacloud
 import (
 	"context"
 	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,6 +23,12 @@ const (
 	EnvAPIKey         = "GRAFANA_CLOUD_API_KEY"
 	EnvTempKeyExpires = "GRAFANA_CLOUD_TEMP_KEY_EXPIRES"
 	EnvTempKeyPrefix  = "GRAFANA_CLOUD_TEMP_KEY_PREFIX"
+	EnvSweepTempKeys  = "GRAFANA_CLOUD_SWEEP_TEMP_KEYS"
+
+	EnvRequestsPerSecond     = "GRAFANA_CLOUD_REQUESTS_PER_SECOND"
+	EnvMaxConcurrentRequests = "GRAFANA_CLOUD_MAX_CONCURRENT_REQUESTS"
+
+	DefaultURL = "https://grafana.com/api"
 )
 
 type Provider struct {
@@ -26,24 +36,50 @@ type Provider struct {
 	Organisation string
 }
 
+// Clients of all providers configured in this process, whose temporary Grafana API keys are deleted by `Shutdown`
+var (
+	clientsMu sync.Mutex
+	clients   []*portal.Client
+)
+
 func NewProvider(version string) func() *schema.Provider {
 	return func() *schema.Provider {
 		p := &schema.Provider{
 			ResourcesMap: map[string]*schema.Resource{
-				"grafanacloud_stack":           resourceStack(),
-				"grafanacloud_grafana_api_key": resourceGrafanaApiKey(),
-				"grafanacloud_portal_api_key":  resourcePortalApiKey(),
+				"grafanacloud_stack":                       resourceStack(),
+				"grafanacloud_grafana_api_key":             resourceGrafanaApiKey(),
+				"grafanacloud_portal_api_key":              resourcePortalApiKey(),
+				"grafanacloud_access_policy":               resourceAccessPolicy(),
+				"grafanacloud_access_policy_token":         resourceAccessPolicyToken(),
+				"grafanacloud_stack_service_account":       resourceStackServiceAccount(),
+				"grafanacloud_stack_service_account_token": resourceStackServiceAccountToken(),
+				"grafanacloud_stack_plugin":                resourceStackPlugin(),
+				"grafanacloud_dashboard":                   resourceDashboard(),
+				"grafanacloud_folder":                      resourceFolder(),
+				"grafanacloud_folder_permission":           resourceFolderPermission(),
+				"grafanacloud_data_source":                 resourceDataSource(),
+				"grafanacloud_rule_group":                  resourceRuleGroup(),
+				"grafanacloud_contact_point":               resourceContactPoint(),
+				"grafanacloud_notification_policy":         resourceNotificationPolicy(),
+				"grafanacloud_mute_timing":                 resourceMuteTiming(),
+				"grafanacloud_prometheus_rule_namespace":   resourcePrometheusRuleNamespace(),
+				"grafanacloud_loki_rule_namespace":         resourceLokiRuleNamespace(),
+				"grafanacloud_alertmanager_config":         resourceAlertmanagerConfig(),
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"grafanacloud_stacks": dataSourceStacks(),
-				"grafanacloud_stack":  dataSourceStack(),
+				"grafanacloud_stacks":         dataSourceStacks(),
+				"grafanacloud_stack":          dataSourceStack(),
+				"grafanacloud_hosted_metrics": dataSourceHostedMetrics(),
+				"grafanacloud_hosted_logs":    dataSourceHostedLogs(),
+				"grafanacloud_hosted_traces":  dataSourceHostedTraces(),
+				"grafanacloud_data_sources":   dataSourceDataSources(),
 			},
 			Schema: map[string]*schema.Schema{
 				"url": {
 					Type:        schema.TypeString,
 					Optional:    true,
 					Description: fmt.Sprintf("Grafana Cloud API endpoint including the final `/api`. Might also be provided via `%s`.", EnvURL),
-					DefaultFunc: schema.EnvDefaultFunc(EnvURL, "https://grafana.com/api"),
+					DefaultFunc: schema.EnvDefaultFunc(EnvURL, DefaultURL),
 				},
 				"api_key": {
 					Type:        schema.TypeString,
@@ -61,7 +97,7 @@ func NewProvider(version string) func() *schema.Provider {
 				"temp_key_expires": {
 					Type:        schema.TypeInt,
 					Optional:    true,
-					Description: fmt.Sprintf("Time after which temporary Grafana API admin tokens used to read Grafana API resources expire. Might also be provided via `%s`", EnvTempKeyExpires),
+					Description: fmt.Sprintf("Time in seconds after which temporary Grafana API admin tokens used to manage Grafana API resources expire. A token is reused per stack until half of this time has passed, and deleted when Terraform is done. Might also be provided via `%s`", EnvTempKeyExpires),
 					DefaultFunc: schema.EnvDefaultFunc(EnvTempKeyExpires, portal.TempKeyDefaultExpires),
 				},
 				"temp_key_prefix": {
@@ -70,6 +106,50 @@ func NewProvider(version string) func() *schema.Provider {
 					Description: fmt.Sprintf("Prefix for temporary Grafana API admin tokens used to read Grafana API resources. Might also be provided via `%s`", EnvTempKeyPrefix),
 					DefaultFunc: schema.EnvDefaultFunc(EnvTempKeyPrefix, portal.TempKeyDefaultPrefix),
 				},
+				"sweep_temp_keys": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: fmt.Sprintf("Whether or not to delete temporary Grafana API admin tokens left behind on a stack once the provider first uses its Grafana API. Tokens are considered left behind if they've expired or have been created longer than `temp_key_expires` ago. Only stacks used by the Terraform run are swept, which costs one additional request per stack. To sweep all stacks of the organisation, run the provider binary with `-sweep-temp-keys` instead. Might also be provided via `%s`", EnvSweepTempKeys),
+					DefaultFunc: schema.EnvDefaultFunc(EnvSweepTempKeys, false),
+				},
+				"requests_per_second": {
+					Type:        schema.TypeFloat,
+					Optional:    true,
+					Description: fmt.Sprintf("Maximum number of requests per second to the Grafana Cloud API, as well as to the Grafana, ruler and Alertmanager APIs of each stack. Rate limited requests are retried with exponential backoff. Set to `0` to disable the limit. Defaults to `%d`. Might also be provided via `%s`", portal.DefaultRequestsPerSecond, EnvRequestsPerSecond),
+					DefaultFunc: schema.EnvDefaultFunc(EnvRequestsPerSecond, portal.DefaultRequestsPerSecond),
+				},
+				"max_concurrent_requests": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Description: fmt.Sprintf("Maximum number of requests in flight to the Grafana Cloud API, as well as to the Grafana, ruler and Alertmanager APIs of each stack. Unlimited if not set, so only Terraform's parallelism applies. Might also be provided via `%s`", EnvMaxConcurrentRequests),
+					DefaultFunc: schema.EnvDefaultFunc(EnvMaxConcurrentRequests, 0),
+				},
+				"stack_credentials": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Long-lived credentials used to authenticate with the Grafana API of stacks instead of temporary Grafana API admin tokens, which are only used for stacks without credentials. Exactly one of `service_account_token` and `access_policy_token` must be set per stack.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"stack": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Slug of the stack.",
+							},
+							"service_account_token": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Sensitive:   true,
+								Description: "Token of a service account of the Grafana instance of the stack. Needs the `Admin` role to manage all Grafana API resources.",
+							},
+							"access_policy_token": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Sensitive:   true,
+								Description: "Token of a Cloud Access Policy with the stack or the organisation as realm.",
+							},
+						},
+					},
+				},
 			},
 		}
 
@@ -93,6 +173,10 @@ func ConfigureProvider(p *schema.Provider, version string) func(context.Context,
 			return nil, diag.FromErr(err)
 		}
 
+		clientsMu.Lock()
+		clients = append(clients, c)
+		clientsMu.Unlock()
+
 		return &Provider{
 			Client:       c,
 			Organisation: org,
@@ -100,6 +184,82 @@ func ConfigureProvider(p *schema.Provider, version string) func(context.Context,
 	}
 }
 
+// Deletes the temporary Grafana API keys of all providers configured in this process. This is meant to be called once
+// the plugin has stopped serving. Terraform doesn't wait long for plugins to exit though, so any keys which couldn't
+// be deleted in time expire after `temp_key_expires` instead.
+func Shutdown(ctx context.Context) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	for _, c := range clients {
+		if err := c.Close(ctx); err != nil {
+			log.Printf("[WARN] %v", err)
+		}
+	}
+
+	clients = nil
+}
+
+// Sweeps temporary Grafana API admin tokens left behind on all stacks of the organisation like `sweep_temp_keys`
+// does, but outside of Terraform. The client is configured by the environment variables of the provider instead.
+func SweepTempKeys(ctx context.Context, version string, dryRun bool) ([]*portal.LeakedTempKey, error) {
+	url := os.Getenv(EnvURL)
+	if url == "" {
+		url = DefaultURL
+	}
+
+	tempKeyExpires := portal.TempKeyDefaultExpires
+	if v := os.Getenv(EnvTempKeyExpires); v != "" {
+		var err error
+		if tempKeyExpires, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("%s must be a number of seconds: %v", EnvTempKeyExpires, err)
+		}
+	}
+
+	tempKeyPrefix := os.Getenv(EnvTempKeyPrefix)
+	if tempKeyPrefix == "" {
+		tempKeyPrefix = portal.TempKeyDefaultPrefix
+	}
+
+	requestsPerSecond := float64(portal.DefaultRequestsPerSecond)
+	if v := os.Getenv(EnvRequestsPerSecond); v != "" {
+		var err error
+		if requestsPerSecond, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("%s must be a number: %v", EnvRequestsPerSecond, err)
+		}
+	}
+
+	maxConcurrentRequests := 0
+	if v := os.Getenv(EnvMaxConcurrentRequests); v != "" {
+		var err error
+		if maxConcurrentRequests, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("%s must be a number: %v", EnvMaxConcurrentRequests, err)
+		}
+	}
+
+	c, err := portal.NewClient(
+		url,
+		os.Getenv(EnvAPIKey),
+		portal.WithUserAgent(NewProvider(version)().UserAgent(Name, version)),
+		portal.WithTempKeyExpires(time.Duration(tempKeyExpires)*time.Second),
+		portal.WithTempKeyPrefix(tempKeyPrefix),
+		portal.WithRateLimit(requestsPerSecond, maxConcurrentRequests),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Sweeping creates temporary keys of its own
+	defer func() {
+		if err := c.Close(ctx); err != nil {
+			log.Printf("[WARN] %v", err)
+		}
+	}()
+
+	return c.SweepTempKeys(ctx, os.Getenv(EnvOrganisation), dryRun)
+}
+
 func buildClient(p *schema.Provider, d *schema.ResourceData, version string) (*portal.Client, error) {
 	url := d.Get("url").(string)
 	apiKey := d.Get("api_key").(string)
@@ -107,6 +267,7 @@ func buildClient(p *schema.Provider, d *schema.ResourceData, version string) (*p
 
 	opts := []portal.ClientOpt{
 		portal.WithUserAgent(userAgent),
+		portal.WithRateLimit(d.Get("requests_per_second").(float64), d.Get("max_concurrent_requests").(int)),
 	}
 
 	if tempKeyExpires, ok := d.GetOk("temp_key_expires"); ok {
@@ -118,5 +279,32 @@ func buildClient(p *schema.Provider, d *schema.ResourceData, version string) (*p
 		opts = append(opts, portal.WithTempKeyPrefix(tempKeyPrefix.(string)))
 	}
 
+	if d.Get("sweep_temp_keys").(bool) {
+		opts = append(opts, portal.WithTempKeySweeping())
+	}
+
+	stacks := make(map[string]bool)
+	for _, c := range d.Get("stack_credentials").([]interface{}) {
+		credentials := c.(map[string]interface{})
+		stack := credentials["stack"].(string)
+		if stacks[stack] {
+			return nil, fmt.Errorf("credentials of stack `%s` are configured more than once", stack)
+		}
+
+		stacks[stack] = true
+		serviceAccountToken := credentials["service_account_token"].(string)
+		accessPolicyToken := credentials["access_policy_token"].(string)
+		if (serviceAccountToken == "") == (accessPolicyToken == "") {
+			return nil, fmt.Errorf("credentials of stack `%s` must set exactly one of `service_account_token` and `access_policy_token`", stack)
+		}
+
+		token := serviceAccountToken
+		if token == "" {
+			token = accessPolicyToken
+		}
+
+		opts = append(opts, portal.WithStackToken(stack, token))
+	}
+
 	return portal.NewClient(url, apiKey, opts...)
 }
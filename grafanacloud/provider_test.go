@@ -1,10 +1,20 @@
 package grafanacloud_test
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/form3tech-oss/terraform-provider-grafanacloud/grafanacloud"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProvider(t *testing.T) {
@@ -13,6 +23,498 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestAccProvider_TempKeyCache(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfig(resourceName),
+				Check:  testAccCheckTempKeyCache("grafanacloud_stack.test"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_SweepTempKeys(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfig(resourceName),
+				Check:  testAccCheckSweepTempKeys("grafanacloud_stack.test"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_SweepTempKeysOnUse(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfig(resourceName),
+				Check:  testAccCheckSweepTempKeysOnUse("grafanacloud_stack.test"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_StackCredentials(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderStackCredentialsConfig(resourceName),
+				Check:  testAccCheckStackCredentials("grafanacloud_stack_service_account_token.test"),
+			},
+		},
+	})
+}
+
+// Checks that a provider with credentials for the stack uses them instead of creating temporary keys.
+func testAccCheckStackCredentials(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		stack := rs.Primary.Attributes["stack"]
+
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, stack)
+		if err != nil {
+			return err
+		}
+
+		configured, err := testAccConfigureProvider(map[string]interface{}{
+			"temp_key_prefix": "stack-credentials",
+			"stack_credentials": []interface{}{
+				map[string]interface{}{
+					"stack":                 stack,
+					"service_account_token": rs.Primary.Attributes["key"],
+				},
+			},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		client, err := configured.Client.GetAuthedGrafanaClient(ctx, configured.Organisation, stack)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.ListAPIKeys(ctx, false); err != nil {
+			return err
+		}
+
+		if err := testAccCheckTempKeyCount(ctx, gc, "stack-credentials", 0); err != nil {
+			return err
+		}
+
+		configured, err = testAccConfigureProvider(map[string]interface{}{
+			"stack_credentials": []interface{}{
+				map[string]interface{}{
+					"stack":                 stack,
+					"service_account_token": "wrong",
+				},
+			},
+		})
+
+		if err != nil {
+			return err
+		}
+
+		client, err = configured.Client.GetAuthedGrafanaClient(ctx, configured.Organisation, stack)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.ListAPIKeys(ctx, false); err == nil {
+			return fmt.Errorf("expected the Grafana API of stack `%s` to reject a wrong token", stack)
+		}
+
+		_, err = testAccConfigureProvider(map[string]interface{}{
+			"stack_credentials": []interface{}{
+				map[string]interface{}{
+					"stack":                 stack,
+					"service_account_token": rs.Primary.Attributes["key"],
+					"access_policy_token":   rs.Primary.Attributes["key"],
+				},
+			},
+		})
+
+		if err == nil {
+			return fmt.Errorf("expected credentials with both tokens to be rejected")
+		}
+
+		return nil
+	}
+}
+
+// Configures a new provider, which is configured by the environment like the provider under test otherwise.
+func testAccConfigureProvider(config map[string]interface{}) (*grafanacloud.Provider, error) {
+	p := grafanacloud.NewProvider("dev")()
+
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to configure provider: %v", diags)
+	}
+
+	return getProvider(p), nil
+}
+
+func testAccProviderStackCredentialsConfig(resourceName string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack_service_account_token" "test" {
+  name = "%s"
+  stack = grafanacloud_stack.test.slug
+  service_account_id = grafanacloud_stack_service_account.test.service_account_id
+}
+`, resourceName) + testAccStackServiceAccountConfig(resourceName, "Admin", false)
+}
+
+func TestProvider_RateLimit(t *testing.T) {
+	if grafanaCloudMock == nil {
+		t.Skipf("%s must be set to test rate limiting against the mock", EnvMock)
+	}
+
+	p, err := testAccConfigureProvider(map[string]interface{}{
+		"requests_per_second": 2,
+	})
+
+	require.NoError(t, err)
+
+	// Configuring the provider and the first request use up the burst, so the others have to wait half a second each
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := p.Client.ListStacks(context.Background(), p.Organisation)
+		require.NoError(t, err)
+	}
+
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(1900*time.Millisecond))
+}
+
+func TestProvider_RetryAfter(t *testing.T) {
+	if grafanaCloudMock == nil {
+		t.Skipf("%s must be set to test rate limiting against the mock", EnvMock)
+	}
+
+	p, err := testAccConfigureProvider(map[string]interface{}{})
+	require.NoError(t, err)
+
+	start := time.Now()
+	grafanaCloudMock.RateLimitNext(2, 2*time.Second)
+	_, err = p.Client.ListStacks(context.Background(), p.Organisation)
+	require.NoError(t, err)
+
+	// Retries wait as long as the API asks for instead of backing off from a second
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(4*time.Second))
+}
+
+func TestProvider_MaxConcurrentRequests(t *testing.T) {
+	if grafanaCloudMock == nil {
+		t.Skipf("%s must be set to test rate limiting against the mock", EnvMock)
+	}
+
+	p, err := testAccConfigureProvider(map[string]interface{}{
+		"max_concurrent_requests": 1,
+	})
+
+	require.NoError(t, err)
+
+	// Requests only release their slot once their response has been read, which must not leak slots either
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for i := 0; i < 3; i++ {
+		_, err := p.Client.ListStacks(ctx, p.Organisation)
+		require.NoError(t, err)
+	}
+}
+
+func TestAccProvider_RulerRetryAfter(t *testing.T) {
+	if grafanaCloudMock == nil {
+		t.Skipf("%s must be set to test rate limiting against the mock", EnvMock)
+	}
+
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfig(resourceName),
+				Check:  testAccCheckRulerRetryAfter("grafanacloud_stack.test"),
+			},
+		},
+	})
+}
+
+// Checks that requests to the ruler of a stack rate limited by the API are retried after as long as it asks for.
+func testAccCheckRulerRetryAfter(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		client, err := p.Client.GetPrometheusRulerClient(ctx, p.Organisation, rs.Primary.Attributes["slug"])
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		grafanaCloudMock.RateLimitNext(2, time.Second)
+		if _, err := client.ListRuleGroups(ctx, "test"); err != nil {
+			return err
+		}
+
+		if waited := time.Since(start); waited < 2*time.Second {
+			return fmt.Errorf("expected rate limited requests to the ruler to be retried after 2s, but took %s", waited)
+		}
+
+		return nil
+	}
+}
+
+// Checks that only temporary keys which aren't used anymore are swept, and only if it's not a dry run.
+func testAccCheckSweepTempKeys(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		stack := rs.Primary.Attributes["slug"]
+
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, stack)
+		if err != nil {
+			return err
+		}
+
+		// Named like a key created at the beginning of the Unix epoch, so it should have expired long ago
+		leaked := fmt.Sprintf("%s-1", p.Client.TempKeyPrefix)
+		for _, name := range []string{leaked, "unrelated"} {
+			_, err := p.Client.CreateGrafanaAPIKey(ctx, &portal.CreateGrafanaAPIKeyInput{
+				Name:          name,
+				Role:          "Viewer",
+				SecondsToLive: 3600,
+				Stack:         stack,
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		keys, err := grafanacloud.SweepTempKeys(ctx, "dev", true)
+		if err != nil {
+			return err
+		}
+
+		if err := testAccCheckSweptTempKeys(keys, stack, leaked); err != nil {
+			return err
+		}
+
+		if err := testAccCheckTempKeyCount(ctx, gc, p.Client.TempKeyPrefix, 2); err != nil {
+			return err
+		}
+
+		keys, err = p.Client.SweepTempKeys(ctx, p.Organisation, false)
+		if err != nil {
+			return err
+		}
+
+		if err := testAccCheckSweptTempKeys(keys, stack, leaked); err != nil {
+			return err
+		}
+
+		if err := testAccCheckTempKeyCount(ctx, gc, p.Client.TempKeyPrefix, 1); err != nil {
+			return err
+		}
+
+		return testAccCheckTempKeyCount(ctx, gc, "unrelated", 1)
+	}
+}
+
+// Checks that a provider with `sweep_temp_keys` sweeps the temporary keys of a stack once it uses the stack.
+func testAccCheckSweepTempKeysOnUse(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		stack := rs.Primary.Attributes["slug"]
+
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, stack)
+		if err != nil {
+			return err
+		}
+
+		// Named like a key created at the beginning of the Unix epoch, so it should have expired long ago
+		leaked := fmt.Sprintf("%s-0", p.Client.TempKeyPrefix)
+		_, err = p.Client.CreateGrafanaAPIKey(ctx, &portal.CreateGrafanaAPIKeyInput{
+			Name:          leaked,
+			Role:          "Viewer",
+			SecondsToLive: 3600,
+			Stack:         stack,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		sweeping, err := testAccConfigureProvider(map[string]interface{}{
+			"sweep_temp_keys": true,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		defer sweeping.Client.Close(ctx)
+
+		// Configuring the provider doesn't sweep yet
+		if err := testAccCheckTempKeyCount(ctx, gc, leaked, 1); err != nil {
+			return err
+		}
+
+		if _, err := sweeping.Client.GetAuthedGrafanaClient(ctx, sweeping.Organisation, stack); err != nil {
+			return err
+		}
+
+		return testAccCheckTempKeyCount(ctx, gc, leaked, 0)
+	}
+}
+
+func testAccCheckSweptTempKeys(keys []*portal.LeakedTempKey, stack, expected string) error {
+	swept := make([]string, 0)
+	for _, key := range keys {
+		if key.Stack == stack {
+			swept = append(swept, key.Name)
+		}
+	}
+
+	if len(swept) != 1 || swept[0] != expected {
+		return fmt.Errorf("swept temporary keys %v on stack `%s`, expected only `%s`", swept, stack, expected)
+	}
+
+	return nil
+}
+
+// Checks that the client using a temporary key is reused until the provider is closed, which deletes the key.
+func testAccCheckTempKeyCache(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		stack := rs.Primary.Attributes["slug"]
+
+		first, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, stack)
+		if err != nil {
+			return err
+		}
+
+		second, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, stack)
+		if err != nil {
+			return err
+		}
+
+		if first != second {
+			return fmt.Errorf("expected the Grafana client of stack `%s` to be cached", stack)
+		}
+
+		if err := testAccCheckTempKeyCount(ctx, first, p.Client.TempKeyPrefix, 1); err != nil {
+			return err
+		}
+
+		if err := p.Client.Close(ctx); err != nil {
+			return err
+		}
+
+		third, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, stack)
+		if err != nil {
+			return err
+		}
+
+		if third == first {
+			return fmt.Errorf("expected the Grafana client of stack `%s` to be replaced after closing", stack)
+		}
+
+		return testAccCheckTempKeyCount(ctx, third, p.Client.TempKeyPrefix, 1)
+	}
+}
+
+func testAccCheckTempKeyCount(ctx context.Context, gc *grafana.Client, prefix string, expected int) error {
+	keys, err := gc.ListAPIKeys(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, key := range keys.Keys {
+		if strings.HasPrefix(key.Name, prefix) {
+			count++
+		}
+	}
+
+	if count != expected {
+		return fmt.Errorf("found %d temporary keys, expected %d", count, expected)
+	}
+
+	return nil
+}
+
 func getProvider(p *schema.Provider) *grafanacloud.Provider {
 	return p.Meta().(*grafanacloud.Provider)
 }
+
+// Stores an attribute of a resource, so later steps can check that it didn't change.
+func testAccStoreAttr(resourceName, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		*value = rs.Primary.Attributes[key]
+		if *value == "" {
+			return fmt.Errorf("resource `%s` has no `%s` set", resourceName, key)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,282 @@
+package grafanacloud
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+var (
+	accessPolicyRealmTypes = []string{portal.AccessPolicyRealmOrg, portal.AccessPolicyRealmStack}
+	accessPolicyScopeRegex = regexp.MustCompile(`^[a-z-]+:[a-z]+$`)
+)
+
+func resourceAccessPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single Cloud Access Policy in Grafana Cloud. Access policies define which scopes tokens created for them have, and which organisation or stacks they can access.",
+		CreateContext: resourceAccessPolicyCreate,
+		ReadContext:   resourceAccessPolicyRead,
+		UpdateContext: resourceAccessPolicyUpdate,
+		DeleteContext: resourceAccessPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the access policy in Terraform, composed as `region/policy_id`.",
+			},
+			"policy_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the access policy in Grafana Cloud.",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  fmt.Sprintf("Region the access policy is stored in. Might be one of %s.", stackRegions),
+				ValidateFunc: ValidateStackRegion(),
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the access policy.",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Display name of the access policy. Defaults to `name` if not set.",
+			},
+			"scopes": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: ValidateAccessPolicyScope(),
+				},
+				Description: "Scopes granted by the access policy, e.g. `metrics:read` or `logs:write`. See https://grafana.com/docs/grafana-cloud/reference/cloud-api/#list-of-scopes for details.",
+			},
+			"realm": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Organisation or stacks the access policy applies to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  fmt.Sprintf("Type of the realm. Might be one of %s.", accessPolicyRealmTypes),
+							ValidateFunc: validation.StringInSlice(accessPolicyRealmTypes, false),
+						},
+						"identifier": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ID of the organisation or stack, depending on `type`.",
+						},
+						"label_policy": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Label selectors restricting which data the access policy grants access to.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"selector": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Label selector, e.g. `{namespace=\"default\"}`.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the access policy was created.",
+			},
+		},
+	}
+}
+
+func ValidateAccessPolicyScope() schema.SchemaValidateFunc {
+	return validation.StringMatch(accessPolicyScopeRegex, "must be of format `resource:action`, e.g. `metrics:read`")
+}
+
+func resourceAccessPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	req := &portal.CreateAccessPolicyInput{
+		Name:        d.Get("name").(string),
+		DisplayName: d.Get("display_name").(string),
+		Scopes:      expandStringSet(d.Get("scopes").(*schema.Set)),
+		Realms:      expandAccessPolicyRealms(d.Get("realm").([]interface{})),
+		Region:      d.Get("region").(string),
+	}
+
+	resp, err := p.Client.CreateAccessPolicy(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(compositeID(req.Region, resp.ID))
+
+	return resourceAccessPolicyRead(ctx, d, m)
+}
+
+func resourceAccessPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	region, id, err := splitCompositeID(d.Id(), "region/policy_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := p.Client.ListAccessPolicies(ctx, region)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy := resp.FindByID(id)
+	if policy == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("policy_id", policy.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("region", region); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", policy.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("display_name", policy.DisplayName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("scopes", policy.Scopes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("realm", flattenAccessPolicyRealms(policy.Realms)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("created_at", policy.CreatedAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceAccessPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	region, id, err := splitCompositeID(d.Id(), "region/policy_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &portal.UpdateAccessPolicyInput{
+		DisplayName: d.Get("display_name").(string),
+		Scopes:      expandStringSet(d.Get("scopes").(*schema.Set)),
+		Realms:      expandAccessPolicyRealms(d.Get("realm").([]interface{})),
+		ID:          id,
+		Region:      region,
+	}
+
+	_, err = p.Client.UpdateAccessPolicy(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAccessPolicyRead(ctx, d, m)
+}
+
+func resourceAccessPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	region, id, err := splitCompositeID(d.Id(), "region/policy_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = p.Client.DeleteAccessPolicy(ctx, region, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandAccessPolicyRealms(realms []interface{}) []*portal.AccessPolicyRealm {
+	result := make([]*portal.AccessPolicyRealm, 0, len(realms))
+
+	for _, r := range realms {
+		realm := r.(map[string]interface{})
+
+		labelPolicies := make([]*portal.AccessPolicyLabelPolicy, 0)
+		for _, lp := range realm["label_policy"].([]interface{}) {
+			labelPolicies = append(labelPolicies, &portal.AccessPolicyLabelPolicy{
+				Selector: lp.(map[string]interface{})["selector"].(string),
+			})
+		}
+
+		result = append(result, &portal.AccessPolicyRealm{
+			Type:          realm["type"].(string),
+			Identifier:    realm["identifier"].(string),
+			LabelPolicies: labelPolicies,
+		})
+	}
+
+	return result
+}
+
+func flattenAccessPolicyRealms(realms []*portal.AccessPolicyRealm) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(realms))
+
+	for _, realm := range realms {
+		labelPolicies := make([]map[string]interface{}, 0, len(realm.LabelPolicies))
+		for _, lp := range realm.LabelPolicies {
+			labelPolicies = append(labelPolicies, map[string]interface{}{
+				"selector": lp.Selector,
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"type":         realm.Type,
+			"identifier":   realm.Identifier,
+			"label_policy": labelPolicies,
+		})
+	}
+
+	return result
+}
+
+func expandStringSet(set *schema.Set) []string {
+	result := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		result = append(result, v.(string))
+	}
+
+	return result
+}
@@ -0,0 +1,159 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/grafanacloud"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAccessPolicyScope(t *testing.T) {
+	fn := grafanacloud.ValidateAccessPolicyScope()
+
+	var tests = []struct {
+		scope string
+		valid bool
+	}{
+		{"metrics:read", true},
+		{"logs:write", true},
+		{"accesspolicies:delete", true},
+		{"metrics", false},
+		{"metrics:", false},
+		{"Metrics:Read", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			warn, err := fn(tt.scope, "scopes")
+			if tt.valid {
+				require.Empty(t, warn)
+				require.Empty(t, err)
+			} else {
+				require.Empty(t, warn)
+				require.NotEmpty(t, err)
+			}
+		})
+	}
+}
+
+func TestAccAccessPolicy_Basic(t *testing.T) {
+	resourceName := strings.ToLower(acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAccessPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccessPolicyConfig(resourceName, `["metrics:read"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccessPolicyExists("grafanacloud_access_policy.test"),
+					resource.TestCheckResourceAttrSet("grafanacloud_access_policy.test", "id"),
+					resource.TestCheckResourceAttrSet("grafanacloud_access_policy.test", "policy_id"),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy.test", "name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy.test", "display_name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy.test", "region", "eu"),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy.test", "scopes.#", "1"),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy.test", "realm.#", "1"),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy.test", "realm.0.type", "stack"),
+					resource.TestCheckResourceAttrPair("grafanacloud_access_policy.test", "realm.0.identifier", "grafanacloud_stack.test", "id"),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy.test", "realm.0.label_policy.0.selector", `{namespace="default"}`),
+				),
+			},
+			{
+				Config: testAccAccessPolicyConfig(resourceName, `["metrics:read", "metrics:write", "logs:write"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccessPolicyExists("grafanacloud_access_policy.test"),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy.test", "scopes.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_access_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAccessPolicyExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		res, err := p.Client.ListAccessPolicies(ctx, rs.Primary.Attributes["region"])
+		if err != nil {
+			return err
+		}
+
+		policy := res.FindByID(rs.Primary.Attributes["policy_id"])
+		if policy == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAccessPolicyDestroy(s *terraform.State) error {
+	ctx := context.Background()
+	p := getProvider(testAccProvider)
+
+	for name, rs := range s.RootModule().Resources {
+		if rs.Type != "grafanacloud_access_policy" {
+			continue
+		}
+
+		res, err := p.Client.ListAccessPolicies(ctx, rs.Primary.Attributes["region"])
+		if err != nil {
+			return err
+		}
+
+		policy := res.FindByID(rs.Primary.Attributes["policy_id"])
+		if policy != nil {
+			return fmt.Errorf("resource `%s` with ID `%s` still exists after destroy", name, rs.Primary.ID)
+		}
+	}
+
+	return testAccCheckStackDestroy(s)
+}
+
+func testAccAccessPolicyConfig(resourceName, scopes string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack" "test" {
+  name              = "%s"
+  slug              = "%s"
+  region_slug       = "eu"
+  delete_protection = false
+}
+
+resource "grafanacloud_access_policy" "test" {
+  name   = "%s"
+  region = grafanacloud_stack.test.region_slug
+  scopes = %s
+
+  realm {
+    type       = "stack"
+    identifier = grafanacloud_stack.test.id
+
+    label_policy {
+      selector = "{namespace=\"default\"}"
+    }
+  }
+}
+`, resourceName, resourceName, resourceName, scopes)
+}
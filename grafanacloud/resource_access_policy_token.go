@@ -0,0 +1,233 @@
+package grafanacloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+func resourceAccessPolicyToken() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single token for a Cloud Access Policy in Grafana Cloud. Notice that the token value will be stored in Terraform state, so make sure to manage your Terraform state safely (see https://www.terraform.io/docs/language/state/sensitive-data.html).",
+		CreateContext: resourceAccessPolicyTokenCreate,
+		ReadContext:   resourceAccessPolicyTokenRead,
+		DeleteContext: resourceAccessPolicyTokenDelete,
+		CustomizeDiff: resourceAccessPolicyTokenCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the token in Terraform, composed as `region/token_id`.",
+			},
+			"token_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the token in Grafana Cloud.",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Region of the access policy this token belongs to.",
+				ValidateFunc: ValidateStackRegion(),
+			},
+			"access_policy_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the access policy to create the token for (`policy_id` of `grafanacloud_access_policy`).",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the token.",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Description: "Display name of the token. Defaults to `name` if not set.",
+			},
+			"expires_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Computed:         true,
+				Description:      "Time at which the token expires (RFC3339 format). The token never expires if neither this nor `seconds_to_live` is set. Once this has passed, the token can't be replaced without changing it, so use `seconds_to_live` to rotate tokens by applying again.",
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTimes,
+				ConflictsWith:    []string{"seconds_to_live"},
+			},
+			"seconds_to_live": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "Time in seconds after which the token automatically expires. Unlike `expires_at`, this is relative to the time the token is created, so expired tokens can be rotated by simply applying again.",
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"expires_at"},
+			},
+			"is_expired": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether or not the token has expired. This field is used internally in order to recreate expired tokens. Set this to `true` to not recreate expired tokens.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The generated token.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the token was created.",
+			},
+		},
+	}
+}
+
+// The API might return timestamps in a different (but equivalent) format than the configured one
+func suppressEquivalentTimes(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
+
+// A token replaced with an `expires_at` which has passed would be rejected by the API or expire right away,
+// and then be replaced again by every apply.
+func resourceAccessPolicyTokenCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if _, ok := d.GetOk("seconds_to_live"); ok {
+		return nil
+	}
+
+	// All attributes force a new token, so there's a replacement if anything changes
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+
+	expiresAt, ok := d.GetOk("expires_at")
+	if !ok {
+		return nil
+	}
+
+	expires, err := time.Parse(time.RFC3339, expiresAt.(string))
+	if err != nil || expires.After(time.Now()) {
+		return nil
+	}
+
+	return fmt.Errorf("`expires_at` %s has passed, so the token can't be created with it. Set a later `expires_at`, or `seconds_to_live` instead to rotate the token by applying again", expiresAt)
+}
+
+func resourceAccessPolicyTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	req := &portal.CreateAccessPolicyTokenInput{
+		AccessPolicyID: d.Get("access_policy_id").(string),
+		Name:           d.Get("name").(string),
+		DisplayName:    d.Get("display_name").(string),
+		ExpiresAt:      d.Get("expires_at").(string),
+		Region:         d.Get("region").(string),
+	}
+
+	if secondsToLive, ok := d.GetOk("seconds_to_live"); ok {
+		expiresAt := time.Now().Add(time.Duration(secondsToLive.(int)) * time.Second)
+		req.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	resp, err := p.Client.CreateAccessPolicyToken(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("token", resp.Token)
+	d.SetId(compositeID(req.Region, resp.ID))
+
+	return resourceAccessPolicyTokenRead(ctx, d, m)
+}
+
+func resourceAccessPolicyTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	region, id, err := splitCompositeID(d.Id(), "region/token_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := p.Client.ListAccessPolicyTokens(ctx, region, d.Get("access_policy_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	token := resp.FindByID(id)
+	if token == nil {
+		d.SetId("")
+		return diags
+	}
+
+	expired, err := token.IsExpired()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("token_id", token.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", token.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("display_name", token.DisplayName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("expires_at", token.ExpiresAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("is_expired", expired); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("created_at", token.CreatedAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceAccessPolicyTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	region, id, err := splitCompositeID(d.Id(), "region/token_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = p.Client.DeleteAccessPolicyToken(ctx, region, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
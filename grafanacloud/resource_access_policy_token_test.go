@@ -0,0 +1,174 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAccessPolicyToken_Basic(t *testing.T) {
+	resourceName := strings.ToLower(acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAccessPolicyTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccessPolicyTokenConfig(resourceName, `expires_at = "2099-01-01T00:00:00Z"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccessPolicyTokenExists("grafanacloud_access_policy_token.test"),
+					resource.TestCheckResourceAttrSet("grafanacloud_access_policy_token.test", "id"),
+					resource.TestCheckResourceAttrSet("grafanacloud_access_policy_token.test", "token_id"),
+					resource.TestCheckResourceAttrSet("grafanacloud_access_policy_token.test", "token"),
+					resource.TestCheckResourceAttrSet("grafanacloud_access_policy_token.test", "created_at"),
+					resource.TestCheckResourceAttrPair("grafanacloud_access_policy_token.test", "access_policy_id", "grafanacloud_access_policy.test", "policy_id"),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy_token.test", "name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy_token.test", "display_name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy_token.test", "expires_at", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy_token.test", "is_expired", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccessPolicyToken_Expiring(t *testing.T) {
+	resourceName := strings.ToLower(acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAccessPolicyTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccessPolicyTokenConfig(resourceName, "seconds_to_live = 2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccessPolicyTokenExists("grafanacloud_access_policy_token.test"),
+					resource.TestMatchResourceAttr("grafanacloud_access_policy_token.test", "expires_at", regexp.MustCompile("^\\d{4}-\\d{2}-\\d{2}T\\d{2}:\\d{2}:\\d{2}.*$")),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy_token.test", "is_expired", "false"),
+				),
+			},
+			{
+				Config: testAccAccessPolicyTokenConfig(resourceName, "seconds_to_live = 2"),
+				// This is supposed to recreate the now expired token
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					testAccSleep(3*time.Second),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy_token.test", "is_expired", "false"),
+				),
+			},
+			{
+				Config: testAccAccessPolicyTokenConfig(resourceName, "seconds_to_live = 2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccessPolicyTokenExists("grafanacloud_access_policy_token.test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccessPolicyToken_ExpiresAtPassed(t *testing.T) {
+	resourceName := strings.ToLower(acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	expiresAt := fmt.Sprintf("expires_at = %q", time.Now().Add(5*time.Second).UTC().Format(time.RFC3339))
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAccessPolicyTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAccessPolicyTokenConfig(resourceName, `expires_at = "2000-01-01T00:00:00Z"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("seconds_to_live"),
+			},
+			{
+				Config: testAccAccessPolicyTokenConfig(resourceName, expiresAt),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccessPolicyTokenExists("grafanacloud_access_policy_token.test"),
+				),
+			},
+			{
+				// Replacing the expired token with the same `expires_at` would loop
+				PreConfig:   func() { time.Sleep(6 * time.Second) },
+				Config:      testAccAccessPolicyTokenConfig(resourceName, expiresAt),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`expires_at` .* has passed"),
+			},
+			{
+				// Keeping the expired token doesn't replace it
+				Config: testAccAccessPolicyTokenConfig(resourceName, expiresAt+"\n  is_expired = true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAccessPolicyTokenExists("grafanacloud_access_policy_token.test"),
+					resource.TestCheckResourceAttr("grafanacloud_access_policy_token.test", "is_expired", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAccessPolicyTokenExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		res, err := p.Client.ListAccessPolicyTokens(ctx, rs.Primary.Attributes["region"], rs.Primary.Attributes["access_policy_id"])
+		if err != nil {
+			return err
+		}
+
+		token := res.FindByID(rs.Primary.Attributes["token_id"])
+		if token == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAccessPolicyTokenDestroy(s *terraform.State) error {
+	ctx := context.Background()
+	p := getProvider(testAccProvider)
+
+	for name, rs := range s.RootModule().Resources {
+		if rs.Type != "grafanacloud_access_policy_token" {
+			continue
+		}
+
+		res, err := p.Client.ListAccessPolicyTokens(ctx, rs.Primary.Attributes["region"], rs.Primary.Attributes["access_policy_id"])
+		if err != nil {
+			return err
+		}
+
+		token := res.FindByID(rs.Primary.Attributes["token_id"])
+		if token != nil {
+			return fmt.Errorf("resource `%s` with ID `%s` still exists after destroy", name, rs.Primary.ID)
+		}
+	}
+
+	return testAccCheckAccessPolicyDestroy(s)
+}
+
+func testAccAccessPolicyTokenConfig(resourceName, expiry string) string {
+	return testAccAccessPolicyConfig(resourceName, `["metrics:write"]`) + fmt.Sprintf(`
+resource "grafanacloud_access_policy_token" "test" {
+  name             = "%s"
+  region           = grafanacloud_access_policy.test.region
+  access_policy_id = grafanacloud_access_policy.test.policy_id
+  %s
+}
+`, resourceName, expiry)
+}
@@ -0,0 +1,216 @@
+package grafanacloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/alertmanager"
+	"gopkg.in/yaml.v3"
+)
+
+// The parts of Alertmanager configuration files validated when planning
+type alertmanagerConfigFile struct {
+	Route     *alertmanagerRoute `yaml:"route"`
+	Receivers []struct {
+		Name string `yaml:"name"`
+	} `yaml:"receivers"`
+}
+
+type alertmanagerRoute struct {
+	Receiver string               `yaml:"receiver"`
+	Routes   []*alertmanagerRoute `yaml:"routes"`
+}
+
+func resourceAlertmanagerConfig() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages the configuration of the Alertmanager instance of a Grafana Cloud stack, which receives the alerts of the rules in its hosted Prometheus and Loki instances. There's only one configuration per stack, so only one of these resources should exist per stack. Deleting the resource resets the Alertmanager instance to its default configuration.",
+		CreateContext: resourceAlertmanagerConfigCreate,
+		ReadContext:   resourceAlertmanagerConfigRead,
+		UpdateContext: resourceAlertmanagerConfigUpdate,
+		DeleteContext: resourceAlertmanagerConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(1),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Alertmanager configuration in Terraform, which is the slug of the stack.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack whose Alertmanager instance to configure.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"config": {
+				Type:         schema.TypeString,
+				Required:     true,
+				StateFunc:    normalizeAlertmanagerConfig,
+				ValidateFunc: validateAlertmanagerConfig,
+				Description:  "Alertmanager configuration file in YAML. The routes and receivers are validated when planning, while the rest of the configuration is only validated by the Alertmanager instance when applying. Formatting and comments don't result in a diff.",
+			},
+			"templates": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateAlertmanagerTemplateNames,
+				Description:  "Notification templates by file name. The file names must be listed in the `templates` of the configuration to be used.",
+			},
+		},
+	}
+}
+
+func validateAlertmanagerConfig(v interface{}, k string) ([]string, []error) {
+	file := &alertmanagerConfigFile{}
+	if err := yaml.Unmarshal([]byte(v.(string)), file); err != nil {
+		return nil, []error{fmt.Errorf("%q is not a valid Alertmanager configuration: %v", k, err)}
+	}
+
+	var errs []error
+	receivers := make(map[string]bool)
+	for i, receiver := range file.Receivers {
+		if receiver.Name == "" {
+			errs = append(errs, fmt.Errorf("%q: receiver %d has no name", k, i+1))
+		}
+
+		if receivers[receiver.Name] {
+			errs = append(errs, fmt.Errorf("%q: receiver name `%s` is not unique", k, receiver.Name))
+		}
+
+		receivers[receiver.Name] = true
+	}
+
+	if file.Route == nil || file.Route.Receiver == "" {
+		return nil, append(errs, fmt.Errorf("%q must have a root route with a default receiver", k))
+	}
+
+	// Child routes inherit the receiver of their parent if not set
+	routes := []*alertmanagerRoute{file.Route}
+	for len(routes) > 0 {
+		route := routes[0]
+		routes = append(routes[1:], route.Routes...)
+
+		if route.Receiver != "" && !receivers[route.Receiver] {
+			errs = append(errs, fmt.Errorf("%q: route references undefined receiver `%s`", k, route.Receiver))
+		}
+	}
+
+	return nil, errs
+}
+
+func validateAlertmanagerTemplateNames(v interface{}, k string) ([]string, []error) {
+	var errs []error
+	for name := range v.(map[string]interface{}) {
+		if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+			errs = append(errs, fmt.Errorf("%q: template name `%s` must be a file name without a path", k, name))
+		}
+	}
+
+	return nil, errs
+}
+
+func resourceAlertmanagerConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	stack := d.Get("stack").(string)
+	d.SetId(stack)
+
+	return resourceAlertmanagerConfigUpdate(ctx, d, m)
+}
+
+func resourceAlertmanagerConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack := d.Id()
+	client, err := p.Client.GetAlertmanagerClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	config, err := client.GetConfig(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if config == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("config", normalizeAlertmanagerConfig(config.AlertmanagerConfig)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("templates", config.TemplateFiles); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceAlertmanagerConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Id()
+	client, err := p.Client.GetAlertmanagerClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	templates := make(map[string]string)
+	for name, content := range d.Get("templates").(map[string]interface{}) {
+		templates[name] = content.(string)
+	}
+
+	req := &alertmanager.Config{
+		AlertmanagerConfig: d.Get("config").(string),
+		TemplateFiles:      templates,
+	}
+
+	err = client.SetConfig(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAlertmanagerConfigRead(ctx, d, m)
+}
+
+func resourceAlertmanagerConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack := d.Id()
+	client, err := p.Client.GetAlertmanagerClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteConfig(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// Normalises the configuration by re-encoding it, so only actual changes to it result in a diff.
+func normalizeAlertmanagerConfig(config interface{}) string {
+	return normalizeYAML(config, func(config string) (interface{}, error) {
+		var parsed interface{}
+		err := yaml.Unmarshal([]byte(config), &parsed)
+		return parsed, err
+	})
+}
@@ -0,0 +1,187 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAlertmanagerConfig_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAlertmanagerConfigConfig(resourceName, `
+    templates:
+      - test.tmpl
+    route:
+      receiver: default
+      group_wait: 30s
+      routes:
+        - receiver: oncall
+          matchers:
+            - severity = critical
+    receivers:
+      - name: default
+        webhook_configs:
+          - url: https://example.com/default
+      - name: oncall
+        webhook_configs:
+          - url: https://example.com/oncall
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAlertmanagerConfigExists("grafanacloud_alertmanager_config.test", "https://example.com/oncall"),
+					resource.TestCheckResourceAttr("grafanacloud_alertmanager_config.test", "id", resourceName+"slug"),
+					resource.TestCheckResourceAttr("grafanacloud_alertmanager_config.test", "templates.%", "1"),
+					resource.TestMatchResourceAttr("grafanacloud_alertmanager_config.test", "config", regexp.MustCompile(`(?s)^receivers:\n.*route:\n.*templates:\n`)),
+				),
+			},
+			{
+				// Only the formatting changed, which must not result in a diff
+				Config: testAccAlertmanagerConfigConfig(resourceName, `
+    # Receivers are defined below
+    route: {receiver: default, group_wait: 30s, routes: [{receiver: oncall, matchers: ["severity = critical"]}]}
+    templates: [test.tmpl]
+    receivers:
+    - name: default
+      webhook_configs: [{url: "https://example.com/default"}]
+    - name: oncall
+      webhook_configs: [{url: "https://example.com/oncall"}]
+`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAlertmanagerConfigConfig(resourceName, `
+    templates:
+      - test.tmpl
+    route:
+      receiver: default
+    receivers:
+      - name: default
+        webhook_configs:
+          - url: https://example.com/default
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAlertmanagerConfigExists("grafanacloud_alertmanager_config.test", "https://example.com/default"),
+					resource.TestCheckResourceAttr("grafanacloud_alertmanager_config.test", "config", "receivers:\n  - name: default\n    webhook_configs:\n      - url: https://example.com/default\nroute:\n  receiver: default\ntemplates:\n  - test.tmpl\n"),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_alertmanager_config.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccAlertmanagerConfig_Invalid(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	invalid := map[string]string{
+		"no route": `
+    receivers:
+      - name: default
+`,
+		"undefined receiver": `
+    route:
+      receiver: default
+      routes:
+        - receiver: oncall
+    receivers:
+      - name: default
+`,
+		"duplicate receiver": `
+    route:
+      receiver: default
+    receivers:
+      - name: default
+      - name: default
+`,
+	}
+
+	for name, config := range invalid {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccAlertmanagerConfigConfig(resourceName, config),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(`config`),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccCheckAlertmanagerConfigExists(resourceName, content string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		client, err := p.Client.GetAlertmanagerClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		config, err := client.GetConfig(ctx)
+		if err != nil {
+			return err
+		}
+
+		if config == nil {
+			return fmt.Errorf("resource `%s` has no configuration in the Alertmanager", resourceName)
+		}
+
+		if !strings.Contains(config.AlertmanagerConfig, content) {
+			return fmt.Errorf("resource `%s` has a configuration in the Alertmanager not containing `%s`", resourceName, content)
+		}
+
+		if _, ok := config.TemplateFiles["test.tmpl"]; !ok {
+			return fmt.Errorf("resource `%s` has no template `test.tmpl` in the Alertmanager", resourceName)
+		}
+
+		return nil
+	}
+}
+
+func testAccAlertmanagerConfigConfig(resourceName, config string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_alertmanager_config" "test" {
+  stack  = grafanacloud_stack.test.slug
+  config = <<-EOT
+%s
+  EOT
+
+  templates = {
+    "test.tmpl" = "{{ define \"test\" }}{{ .CommonLabels.alertname }}{{ end }}"
+  }
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, config, resourceName, resourceName)
+}
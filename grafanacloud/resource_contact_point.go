@@ -0,0 +1,570 @@
+package grafanacloud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+// Integrations of a contact point by their type in Grafana, which is also their block name.
+type contactPointIntegration struct {
+	name        string
+	description string
+	settings    []*contactPointSetting
+
+	// Attributes of which at least one must be set, as Grafana rejects the integration otherwise
+	requiredOneOf []string
+}
+
+// Maps an attribute of an integration block to a setting in Grafana. Settings of TypeList are
+// lists of strings, which Grafana stores as a single string separated by semicolons.
+type contactPointSetting struct {
+	attribute   string
+	key         string
+	valueType   schema.ValueType
+	required    bool
+	secure      bool
+	description string
+}
+
+var (
+	contactPointIntegrations = []*contactPointIntegration{
+		{
+			name:        "email",
+			description: "Sends notifications by email.",
+			settings: []*contactPointSetting{
+				{attribute: "addresses", key: "addresses", valueType: schema.TypeList, required: true, description: "Email addresses to send notifications to."},
+				{attribute: "single_email", key: "singleEmail", valueType: schema.TypeBool, description: "Whether or not to send a single email to all addresses instead of one email per address."},
+				{attribute: "subject", key: "subject", valueType: schema.TypeString, description: "Templated subject of the email."},
+				{attribute: "message", key: "message", valueType: schema.TypeString, description: "Templated message of the email."},
+			},
+		},
+		{
+			name:        "slack",
+			description: "Sends notifications to a Slack channel, using either an incoming webhook or a bot token.",
+			settings: []*contactPointSetting{
+				{attribute: "url", key: "url", valueType: schema.TypeString, secure: true, description: "URL of the incoming webhook. Either this or `token` must be set."},
+				{attribute: "token", key: "token", valueType: schema.TypeString, secure: true, description: "Bot token used to post messages. Either this or `url` must be set."},
+				{attribute: "recipient", key: "recipient", valueType: schema.TypeString, description: "Channel, private group or user to post to. Required if `token` is set."},
+				{attribute: "username", key: "username", valueType: schema.TypeString, description: "User name of the bot posting the messages."},
+				{attribute: "mention_channel", key: "mentionChannel", valueType: schema.TypeString, description: "Whether to mention `here` or the whole `channel` in messages."},
+				{attribute: "title", key: "title", valueType: schema.TypeString, description: "Templated title of the message."},
+				{attribute: "text", key: "text", valueType: schema.TypeString, description: "Templated body of the message."},
+			},
+			requiredOneOf: []string{"url", "token"},
+		},
+		{
+			name:        "pagerduty",
+			description: "Sends notifications to PagerDuty using the Events API v2.",
+			settings: []*contactPointSetting{
+				{attribute: "integration_key", key: "integrationKey", valueType: schema.TypeString, required: true, secure: true, description: "Integration key of the PagerDuty service."},
+				{attribute: "severity", key: "severity", valueType: schema.TypeString, description: "Severity of the created incidents, e.g. `critical`."},
+				{attribute: "class", key: "class", valueType: schema.TypeString, description: "Class or type of the event."},
+				{attribute: "component", key: "component", valueType: schema.TypeString, description: "Component of the source machine responsible for the event."},
+				{attribute: "group", key: "group", valueType: schema.TypeString, description: "Logical grouping of components of a service."},
+				{attribute: "summary", key: "summary", valueType: schema.TypeString, description: "Templated summary of the event."},
+			},
+		},
+		{
+			name:        "webhook",
+			description: "Sends notifications as JSON to an HTTP endpoint.",
+			settings: []*contactPointSetting{
+				{attribute: "url", key: "url", valueType: schema.TypeString, required: true, description: "URL of the endpoint."},
+				{attribute: "http_method", key: "httpMethod", valueType: schema.TypeString, description: "HTTP method of the requests, `POST` if not set."},
+				{attribute: "basic_auth_user", key: "username", valueType: schema.TypeString, description: "User name for basic authentication."},
+				{attribute: "basic_auth_password", key: "password", valueType: schema.TypeString, secure: true, description: "Password for basic authentication."},
+				{attribute: "max_alerts", key: "maxAlerts", valueType: schema.TypeInt, description: "Maximum number of alerts per request, unlimited if not set."},
+			},
+		},
+	}
+
+	contactPointAddressSeparator = regexp.MustCompile(`[;,\n]`)
+)
+
+func resourceContactPoint() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the contact point in Terraform, composed as `stack/name`.",
+		},
+		"stack": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Grafana Cloud stack to create the contact point in.",
+		},
+		"organisation": resourceOrganisationSchema(),
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the contact point, referenced by notification policies.",
+		},
+	}
+
+	integrations := make([]string, 0, len(contactPointIntegrations))
+	for _, integration := range contactPointIntegrations {
+		integrations = append(integrations, integration.name)
+	}
+
+	for _, integration := range contactPointIntegrations {
+		s[integration.name] = &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			Description:  integration.description,
+			Elem:         contactPointIntegrationSchema(integration),
+			AtLeastOneOf: integrations,
+		}
+	}
+
+	return &schema.Resource{
+		Description:   "Manages a single Grafana Alerting contact point on a Grafana instance inside a Grafana Cloud stack. A contact point notifies all of its integrations, e.g. an email and a Slack integration. Notice that secure settings such as webhook URLs or integration keys will be stored in Terraform state, so make sure to manage your Terraform state safely (see https://www.terraform.io/docs/language/state/sensitive-data.html).",
+		CreateContext: resourceContactPointCreate,
+		ReadContext:   resourceContactPointRead,
+		UpdateContext: resourceContactPointUpdate,
+		DeleteContext: resourceContactPointDelete,
+		CustomizeDiff: resourceContactPointCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(2),
+		},
+		Schema: s,
+	}
+}
+
+func contactPointIntegrationSchema(integration *contactPointIntegration) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"uid": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "UID of the integration in Grafana.",
+		},
+		"disable_resolve_message": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether or not to skip notifying when alerts are resolved.",
+		},
+	}
+
+	for _, setting := range integration.settings {
+		attr := &schema.Schema{
+			Type:        setting.valueType,
+			Required:    setting.required,
+			Optional:    !setting.required,
+			Sensitive:   setting.secure,
+			Description: setting.description,
+		}
+
+		if setting.valueType == schema.TypeList {
+			attr.Elem = &schema.Schema{Type: schema.TypeString}
+		}
+
+		s[setting.attribute] = attr
+	}
+
+	return &schema.Resource{Schema: s}
+}
+
+func resourceContactPointCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, integration := range contactPointIntegrations {
+		if len(integration.requiredOneOf) == 0 {
+			continue
+		}
+
+		for i := range d.Get(integration.name).([]interface{}) {
+			set := false
+			for _, attribute := range integration.requiredOneOf {
+				key := fmt.Sprintf("%s.%d.%s", integration.name, i, attribute)
+
+				// Values which are only known after apply might be set
+				if !d.NewValueKnown(key) || d.Get(key).(string) != "" {
+					set = true
+				}
+			}
+
+			if !set {
+				return fmt.Errorf("`%s` block %d must set one of `%s`", integration.name, i, strings.Join(integration.requiredOneOf, "`, `"))
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceContactPointCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	existing, err := client.ListContactPoints(ctx, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Integrations would otherwise be added to the existing contact point
+	if len(existing.FilterByName(name)) > 0 {
+		return diag.Errorf("contact point `%s` already exists, import it instead", name)
+	}
+
+	// Integrations created before a failure are tracked in state this way
+	d.SetId(compositeID(stack, name))
+
+	for _, cp := range expandContactPoint(d, name) {
+		_, err := client.CreateContactPoint(ctx, cp)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceContactPointRead(ctx, d, m)
+}
+
+func resourceContactPointRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack, name, err := splitCompositeID(d.Id(), "stack/name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.ListContactPoints(ctx, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	contactPoints := resp.FilterByName(name)
+	if len(contactPoints) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, integration := range contactPointIntegrations {
+		blocks := flattenContactPointIntegration(integration, contactPoints, d.Get(integration.name).([]interface{}))
+		if err := d.Set(integration.name, blocks); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+func resourceContactPointUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack, name, err := splitCompositeID(d.Id(), "stack/name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	existing, err := client.ListContactPoints(ctx, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Integrations are deleted last, so the contact point never ends up without any integration
+	keep := make(map[string]bool)
+	for _, cp := range matchContactPointIntegrations(expandContactPoint(d, name), existing.FilterByName(name)) {
+		if cp.UID != "" {
+			keep[cp.UID] = true
+			if err := client.UpdateContactPoint(ctx, cp); err != nil {
+				return diag.FromErr(err)
+			}
+
+			continue
+		}
+
+		if _, err := client.CreateContactPoint(ctx, cp); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	for _, cp := range existing.FilterByName(name) {
+		if !keep[cp.UID] {
+			if err := client.DeleteContactPoint(ctx, cp.UID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return resourceContactPointRead(ctx, d, m)
+}
+
+func resourceContactPointDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, name, err := splitCompositeID(d.Id(), "stack/name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.ListContactPoints(ctx, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, cp := range resp.FilterByName(name) {
+		if err := client.DeleteContactPoint(ctx, cp.UID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandContactPoint(d *schema.ResourceData, name string) []*grafana.ContactPoint {
+	result := make([]*grafana.ContactPoint, 0)
+
+	for _, integration := range contactPointIntegrations {
+		for _, b := range d.Get(integration.name).([]interface{}) {
+			result = append(result, expandContactPointIntegration(integration, b.(map[string]interface{}), name))
+		}
+	}
+
+	return result
+}
+
+func expandContactPointIntegration(integration *contactPointIntegration, block map[string]interface{}, name string) *grafana.ContactPoint {
+	settings := make(map[string]interface{})
+	for _, setting := range integration.settings {
+		if v := expandContactPointSetting(setting, block[setting.attribute]); v != nil {
+			settings[setting.key] = v
+		}
+	}
+
+	return &grafana.ContactPoint{
+		UID:                   block["uid"].(string),
+		Name:                  name,
+		Type:                  integration.name,
+		Settings:              settings,
+		DisableResolveMessage: block["disable_resolve_message"].(bool),
+	}
+}
+
+// Integration blocks are lists, so Terraform keeps the computed UID of a block at its position.
+// Removing a block would move its UID onto the following block, so blocks are matched to the
+// existing integrations by their settings first, and only by their UID otherwise. Blocks without a
+// matching integration get no UID, so they're created.
+func matchContactPointIntegrations(cps []*grafana.ContactPoint, existing []*grafana.ContactPoint) []*grafana.ContactPoint {
+	claimed := make(map[string]bool)
+	matched := make([]bool, len(cps))
+
+	for i, cp := range cps {
+		for _, e := range existing {
+			if !claimed[e.UID] && sameContactPointSettings(cp, e) {
+				cp.UID = e.UID
+				claimed[e.UID] = true
+				matched[i] = true
+				break
+			}
+		}
+	}
+
+	for i, cp := range cps {
+		if matched[i] {
+			continue
+		}
+
+		uid := cp.UID
+		cp.UID = ""
+		for _, e := range existing {
+			if e.UID == uid && e.Type == cp.Type && !claimed[uid] {
+				cp.UID = uid
+				claimed[uid] = true
+			}
+		}
+	}
+
+	return cps
+}
+
+// Whether both integrations have the same type and settings. Grafana never returns the values of
+// secure settings, so they're ignored.
+func sameContactPointSettings(a, b *grafana.ContactPoint) bool {
+	if a.Type != b.Type || a.DisableResolveMessage != b.DisableResolveMessage {
+		return false
+	}
+
+	for _, integration := range contactPointIntegrations {
+		if integration.name != a.Type {
+			continue
+		}
+
+		for _, setting := range integration.settings {
+			if setting.secure {
+				continue
+			}
+
+			if !reflect.DeepEqual(flattenContactPointSetting(setting, a.Settings[setting.key]), flattenContactPointSetting(setting, b.Settings[setting.key])) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Returns nil for unset values, so they're omitted from the settings.
+func expandContactPointSetting(setting *contactPointSetting, v interface{}) interface{} {
+	switch setting.valueType {
+	case schema.TypeList:
+		if values := expandStringList(v.([]interface{})); len(values) > 0 {
+			return strings.Join(values, ";")
+		}
+	case schema.TypeBool:
+		if v.(bool) {
+			return true
+		}
+	case schema.TypeInt:
+		if v.(int) != 0 {
+			return v.(int)
+		}
+	default:
+		if v.(string) != "" {
+			return v.(string)
+		}
+	}
+
+	return nil
+}
+
+// Grafana never returns the values of secure settings, so they're kept from the configured blocks
+// of the integration. Like on update, blocks are matched by their settings first, and only by their
+// position otherwise.
+func flattenContactPointIntegration(integration *contactPointIntegration, contactPoints []*grafana.ContactPoint, configured []interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+
+	integrations := make([]*grafana.ContactPoint, 0)
+	for _, cp := range contactPoints {
+		if cp.Type == integration.name {
+			integrations = append(integrations, cp)
+		}
+	}
+
+	blocks := make([]*grafana.ContactPoint, len(configured))
+	for i, b := range configured {
+		if b != nil {
+			blocks[i] = expandContactPointIntegration(integration, b.(map[string]interface{}), "")
+		}
+	}
+
+	previous := make([]map[string]interface{}, len(integrations))
+	claimed := make([]bool, len(configured))
+	for i, cp := range integrations {
+		for j, block := range blocks {
+			if block != nil && !claimed[j] && sameContactPointSettings(block, cp) {
+				previous[i] = configured[j].(map[string]interface{})
+				claimed[j] = true
+				break
+			}
+		}
+	}
+
+	for i, cp := range integrations {
+		if previous[i] != nil || i >= len(blocks) || blocks[i] == nil || claimed[i] {
+			continue
+		}
+
+		if uid := blocks[i].UID; uid == "" || uid == cp.UID {
+			previous[i] = configured[i].(map[string]interface{})
+			claimed[i] = true
+		}
+	}
+
+	for i, cp := range integrations {
+
+		block := map[string]interface{}{
+			"uid":                     cp.UID,
+			"disable_resolve_message": cp.DisableResolveMessage,
+		}
+
+		for _, setting := range integration.settings {
+			v := cp.Settings[setting.key]
+			if v == grafana.RedactedSetting {
+				v = nil
+				if previous[i] != nil {
+					v = previous[i][setting.attribute]
+				}
+			}
+
+			block[setting.attribute] = flattenContactPointSetting(setting, v)
+		}
+
+		result = append(result, block)
+	}
+
+	return result
+}
+
+func flattenContactPointSetting(setting *contactPointSetting, v interface{}) interface{} {
+	switch setting.valueType {
+	case schema.TypeList:
+		values := make([]string, 0)
+		for _, value := range contactPointAddressSeparator.Split(fmt.Sprint(valueOrEmpty(v)), -1) {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+
+		return values
+	case schema.TypeBool:
+		b, _ := strconv.ParseBool(fmt.Sprint(valueOrEmpty(v)))
+		return b
+	case schema.TypeInt:
+		// JSON numbers are decoded as float64, but some settings are stored as strings
+		i, _ := strconv.ParseFloat(fmt.Sprint(valueOrEmpty(v)), 64)
+		return int(i)
+	default:
+		return fmt.Sprint(valueOrEmpty(v))
+	}
+}
+
+func valueOrEmpty(v interface{}) interface{} {
+	if v == nil {
+		return ""
+	}
+
+	return v
+}
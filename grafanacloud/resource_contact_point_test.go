@@ -0,0 +1,234 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccContactPoint_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccContactPointConfig(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContactPointExists("grafanacloud_contact_point.test", 2),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "id", resourceName+"slug/"+resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "email.#", "1"),
+					resource.TestCheckResourceAttrSet("grafanacloud_contact_point.test", "email.0.uid"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "email.0.addresses.#", "2"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "email.0.addresses.1", "oncall@example.com"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "email.0.single_email", "true"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.#", "1"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.0.url", "https://hooks.slack.com/services/secret"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.0.recipient", "#alerts"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.0.disable_resolve_message", "true"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "pagerduty.#", "0"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "webhook.#", "0"),
+				),
+			},
+			{
+				Config: testAccContactPointConfigUpdated(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContactPointExists("grafanacloud_contact_point.test", 3),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "email.0.addresses.#", "1"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "email.0.single_email", "false"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.#", "0"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "pagerduty.#", "1"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "pagerduty.0.integration_key", "secret-key"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "pagerduty.0.severity", "critical"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "webhook.#", "1"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "webhook.0.url", "https://oncall.example.com/alerts"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "webhook.0.basic_auth_password", "secret-password"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "webhook.0.max_alerts", "10"),
+				),
+			},
+			{
+				ResourceName:            "grafanacloud_contact_point.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"pagerduty.0.integration_key", "webhook.0.basic_auth_password"},
+			},
+		},
+	})
+}
+
+func TestAccContactPoint_RemoveIntegration(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	var uid string
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccContactPointConfigSlack(resourceName, "first", "second", "third"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContactPointExists("grafanacloud_contact_point.test", 3),
+					testAccStoreAttr("grafanacloud_contact_point.test", "slack.1.uid", &uid),
+				),
+			},
+			{
+				// The remaining integrations keep their UIDs instead of taking them over by position
+				Config: testAccContactPointConfigSlack(resourceName, "second", "third"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContactPointExists("grafanacloud_contact_point.test", 2),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.#", "2"),
+					resource.TestCheckResourceAttrPtr("grafanacloud_contact_point.test", "slack.0.uid", &uid),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.0.recipient", "#second"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.0.url", "https://hooks.slack.com/services/second"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.1.recipient", "#third"),
+					resource.TestCheckResourceAttr("grafanacloud_contact_point.test", "slack.1.url", "https://hooks.slack.com/services/third"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContactPoint_SlackWithoutURLOrToken(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "grafanacloud_contact_point" "test" {
+  stack = "%sslug"
+  name  = "%s"
+
+  slack {
+    recipient = "#alerts"
+  }
+}
+`, resourceName, resourceName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`slack` block 0 must set one of `url`, `token`"),
+			},
+		},
+	})
+}
+
+func testAccCheckContactPointExists(resourceName string, integrations int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		resp, err := gc.ListContactPoints(ctx, rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+
+		if n := len(resp.FilterByName(rs.Primary.Attributes["name"])); n != integrations {
+			return fmt.Errorf("resource `%s` has %d integrations in Grafana, expected %d", resourceName, n, integrations)
+		}
+
+		return nil
+	}
+}
+
+func testAccContactPointConfig(resourceName string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_contact_point" "test" {
+  stack = grafanacloud_stack.test.slug
+  name  = "%s"
+
+  email {
+    addresses    = ["team@example.com", "oncall@example.com"]
+    single_email = true
+  }
+
+  slack {
+    url                     = "https://hooks.slack.com/services/secret"
+    recipient               = "#alerts"
+    disable_resolve_message = true
+  }
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, resourceName, resourceName)
+}
+
+func testAccContactPointConfigUpdated(resourceName string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_contact_point" "test" {
+  stack = grafanacloud_stack.test.slug
+  name  = "%s"
+
+  email {
+    addresses = ["team@example.com"]
+  }
+
+  pagerduty {
+    integration_key = "secret-key"
+    severity        = "critical"
+  }
+
+  webhook {
+    url                 = "https://oncall.example.com/alerts"
+    basic_auth_user     = "grafana"
+    basic_auth_password = "secret-password"
+    max_alerts          = 10
+  }
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, resourceName, resourceName)
+}
+
+func testAccContactPointConfigSlack(resourceName string, channels ...string) string {
+	integrations := ""
+	for _, channel := range channels {
+		integrations += fmt.Sprintf(`
+  slack {
+    url       = "https://hooks.slack.com/services/%s"
+    recipient = "#%s"
+  }
+`, channel, channel)
+	}
+
+	return fmt.Sprintf(`
+resource "grafanacloud_contact_point" "test" {
+  stack = grafanacloud_stack.test.slug
+  name  = "%s"
+%s}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, integrations, resourceName, resourceName)
+}
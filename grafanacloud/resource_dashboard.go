@@ -0,0 +1,300 @@
+package grafanacloud
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+func resourceDashboard() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a single dashboard on a Grafana instance inside a Grafana Cloud stack.\n\n" +
+			"Changes made to the dashboard outside of Terraform are detected by its version, and reverted on the next apply. " +
+			"Unless `overwrite` is set, applying fails if the dashboard is changed between refreshing and applying.",
+		CreateContext: resourceDashboardCreate,
+		ReadContext:   resourceDashboardRead,
+		UpdateContext: resourceDashboardUpdate,
+		DeleteContext: resourceDashboardDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(2),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the dashboard in Terraform, composed as `stack/uid`.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack to create the dashboard in.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"config_json": {
+				Type:         schema.TypeString,
+				Required:     true,
+				StateFunc:    normalizeDashboardConfigJSON,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The complete dashboard model JSON. The `id` and `version` fields are managed by Grafana and ignored.",
+			},
+			"folder": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "UID of the folder to store the dashboard in. Defaults to the General folder.",
+			},
+			"overwrite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether or not to overwrite changes made to the dashboard between refreshing and applying.",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UID of the dashboard. Taken from `config_json` if set there, otherwise generated by Grafana.",
+			},
+			"dashboard_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Numeric ID of the dashboard in Grafana.",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the dashboard, incremented by Grafana whenever the dashboard is saved.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Path of the dashboard relative to the Grafana instance URL.",
+			},
+		},
+	}
+}
+
+func resourceDashboardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	model, err := expandDashboardConfigJSON(d.Get("config_json").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.SaveDashboardInput{
+		Dashboard: model,
+		FolderUID: d.Get("folder").(string),
+		Overwrite: d.Get("overwrite").(bool),
+	}
+
+	resp, err := client.SaveDashboard(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(compositeID(stack, resp.UID))
+
+	// The version is set before reading the dashboard, so the configured JSON isn't mistaken for drift
+	if err := d.Set("version", resp.Version); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDashboardRead(ctx, d, m)
+}
+
+func resourceDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack, uid, err := splitCompositeID(d.Id(), "stack/uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dashboard, err := client.GetDashboard(ctx, uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if dashboard == nil {
+		d.SetId("")
+		return diags
+	}
+
+	// Grafana adds defaults to the dashboard model, so the model is only taken over from Grafana if the
+	// dashboard has been changed since Terraform last saved it. This also covers importing dashboards.
+	if dashboard.Meta.Version != d.Get("version").(int) {
+		// Don't report the UID as drift if it was generated by Grafana
+		if !dashboardConfigHasUID(d.Get("config_json").(string)) && d.Get("version").(int) != 0 {
+			delete(dashboard.Dashboard, "uid")
+		}
+
+		config, err := json.Marshal(dashboard.Dashboard)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("config_json", normalizeDashboardConfigJSON(string(config))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("uid", uid); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("dashboard_id", dashboardID(dashboard.Dashboard)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("folder", dashboard.Meta.FolderUID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("version", dashboard.Meta.Version); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("url", dashboard.Meta.URL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDashboardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack, uid, err := splitCompositeID(d.Id(), "stack/uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	model, err := expandDashboardConfigJSON(d.Get("config_json").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Saving the dashboard with the version it had when it was last read makes Grafana refuse changes
+	// made in the meantime, unless `overwrite` is set
+	if configUID, _ := model["uid"].(string); configUID == "" {
+		model["uid"] = uid
+	}
+
+	if model["uid"] == uid {
+		model["version"] = d.Get("version").(int)
+	}
+
+	req := &grafana.SaveDashboardInput{
+		Dashboard: model,
+		FolderUID: d.Get("folder").(string),
+		Overwrite: d.Get("overwrite").(bool),
+	}
+
+	resp, err := client.SaveDashboard(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// A changed UID in the configured JSON results in a new dashboard
+	if resp.UID != uid {
+		if err := client.DeleteDashboard(ctx, uid); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(compositeID(stack, resp.UID))
+	}
+
+	if err := d.Set("version", resp.Version); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDashboardRead(ctx, d, m)
+}
+
+func resourceDashboardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, uid, err := splitCompositeID(d.Id(), "stack/uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteDashboard(ctx, uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// Parses the dashboard model and removes the fields managed by Grafana.
+func expandDashboardConfigJSON(config string) (map[string]interface{}, error) {
+	model := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(config), &model); err != nil {
+		return nil, err
+	}
+
+	delete(model, "id")
+	delete(model, "version")
+
+	return model, nil
+}
+
+// Normalises the dashboard model JSON, so only actual changes to the dashboard result in a diff.
+func normalizeDashboardConfigJSON(config interface{}) string {
+	return normalizeJSON(config, func(config string) (interface{}, error) {
+		return expandDashboardConfigJSON(config)
+	})
+}
+
+func dashboardConfigHasUID(config string) bool {
+	model, err := expandDashboardConfigJSON(config)
+	if err != nil {
+		return false
+	}
+
+	uid, _ := model["uid"].(string)
+	return uid != ""
+}
+
+func dashboardID(model map[string]interface{}) int {
+	// JSON numbers are decoded as float64
+	id, _ := model["id"].(float64)
+	return int(id)
+}
@@ -0,0 +1,206 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+func TestAccDashboard_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardConfig(resourceName, "Initial"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDashboardTitle("grafanacloud_dashboard.test", "Initial"),
+					resource.TestCheckResourceAttr("grafanacloud_dashboard.test", "id", resourceName+"slug/"+resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_dashboard.test", "uid", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_dashboard.test", "version", "1"),
+					resource.TestCheckResourceAttr("grafanacloud_dashboard.test", "folder", ""),
+					resource.TestCheckResourceAttr("grafanacloud_dashboard.test", "overwrite", "false"),
+					resource.TestCheckResourceAttrSet("grafanacloud_dashboard.test", "dashboard_id"),
+					resource.TestCheckResourceAttrSet("grafanacloud_dashboard.test", "url"),
+				),
+			},
+			{
+				Config: testAccDashboardConfig(resourceName, "Updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDashboardTitle("grafanacloud_dashboard.test", "Updated"),
+					resource.TestCheckResourceAttr("grafanacloud_dashboard.test", "uid", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_dashboard.test", "version", "2"),
+				),
+			},
+			{
+				ResourceName:            "grafanacloud_dashboard.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"overwrite"},
+			},
+		},
+	})
+}
+
+func TestAccDashboard_GeneratedUID(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardConfigWithoutUID(resourceName, "Initial"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDashboardTitle("grafanacloud_dashboard.test", "Initial"),
+					resource.TestCheckResourceAttrSet("grafanacloud_dashboard.test", "uid"),
+				),
+			},
+			{
+				Config: testAccDashboardConfigWithoutUID(resourceName, "Updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDashboardTitle("grafanacloud_dashboard.test", "Updated"),
+					resource.TestCheckResourceAttr("grafanacloud_dashboard.test", "version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDashboard_Drift(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDashboardConfigWithoutUID(resourceName, "Initial"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDashboardTitle("grafanacloud_dashboard.test", "Initial"),
+				),
+			},
+			{
+				Config: testAccDashboardConfigWithoutUID(resourceName, "Initial"),
+				// Changing the dashboard outside of Terraform is supposed to be detected
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					testAccChangeDashboardTitle("grafanacloud_dashboard.test", "Changed"),
+				),
+			},
+			{
+				Config: testAccDashboardConfigWithoutUID(resourceName, "Initial"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDashboardTitle("grafanacloud_dashboard.test", "Initial"),
+					resource.TestCheckResourceAttr("grafanacloud_dashboard.test", "version", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGetDashboard(ctx context.Context, s *terraform.State, resourceName string) (*grafana.Client, *grafana.Dashboard, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, nil, fmt.Errorf("resource `%s` not found", resourceName)
+	}
+
+	if rs.Primary.ID == "" {
+		return nil, nil, fmt.Errorf("resource `%s` has no ID set", resourceName)
+	}
+
+	p := getProvider(testAccProvider)
+	gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dashboard, err := gc.GetDashboard(ctx, strings.SplitN(rs.Primary.ID, "/", 2)[1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if dashboard == nil {
+		return nil, nil, fmt.Errorf("resource `%s` not found via API", resourceName)
+	}
+
+	return gc, dashboard, nil
+}
+
+func testAccCheckDashboardTitle(resourceName, title string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		_, dashboard, err := testAccGetDashboard(context.Background(), s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		if dashboard.Dashboard["title"] != title {
+			return fmt.Errorf("resource `%s` has title `%v`, expected `%s`", resourceName, dashboard.Dashboard["title"], title)
+		}
+
+		return nil
+	}
+}
+
+func testAccChangeDashboardTitle(resourceName, title string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		gc, dashboard, err := testAccGetDashboard(ctx, s, resourceName)
+		if err != nil {
+			return err
+		}
+
+		dashboard.Dashboard["title"] = title
+		_, err = gc.SaveDashboard(ctx, &grafana.SaveDashboardInput{
+			Dashboard: dashboard.Dashboard,
+			Overwrite: true,
+		})
+
+		return err
+	}
+}
+
+func testAccDashboardConfig(resourceName, title string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_dashboard" "test" {
+  stack = grafanacloud_stack.test.slug
+  config_json = jsonencode({
+    uid   = "%s"
+    title = "%s"
+    panels = []
+  })
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, title, resourceName, resourceName)
+}
+
+func testAccDashboardConfigWithoutUID(resourceName, title string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_dashboard" "test" {
+  stack = grafanacloud_stack.test.slug
+  config_json = jsonencode({
+    title = "%s"
+    panels = []
+  })
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, title, resourceName, resourceName)
+}
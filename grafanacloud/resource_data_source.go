@@ -0,0 +1,338 @@
+package grafanacloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+var (
+	datasourceAccessModes = []string{"proxy", "direct"}
+)
+
+func resourceDataSource() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single data source on a Grafana instance inside a Grafana Cloud stack. Notice that `secure_json_data` will be stored in Terraform state, so make sure to manage your Terraform state safely (see https://www.terraform.io/docs/language/state/sensitive-data.html).",
+		CreateContext: resourceDataSourceCreate,
+		ReadContext:   resourceDataSourceRead,
+		UpdateContext: resourceDataSourceUpdate,
+		DeleteContext: resourceDataSourceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(2),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the data source in Terraform, composed as `stack/uid`.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack to create the data source in.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "UID of the data source. Generated by Grafana if not set.",
+			},
+			"datasource_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Numeric ID of the data source in Grafana.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the data source, unique within the stack.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Type of the data source, e.g. `prometheus`, `loki` or `elasticsearch`.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the data source.",
+			},
+			"access": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "proxy",
+				Description:  fmt.Sprintf("How Grafana accesses the data source. Might be one of %s.", datasourceAccessModes),
+				ValidateFunc: validation.StringInSlice(datasourceAccessModes, false),
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether or not this is the default data source of the stack.",
+			},
+			"basic_auth_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether or not to use basic authentication when accessing the data source. The password is set as `basicAuthPassword` in `secure_json_data`.",
+			},
+			"basic_auth_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User name for basic authentication.",
+			},
+			"json_data": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				StateFunc:    normalizeDatasourceJSONData,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "Settings of the data source as JSON object, depending on its type.",
+			},
+			"secure_json_data": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Secret settings of the data source, e.g. passwords or tokens. These are write-only in Grafana, so changes made outside of Terraform are only detected if a setting is removed. Settings removed from the configuration are cleared in Grafana.",
+			},
+		},
+	}
+}
+
+func resourceDataSourceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	jsonData, err := expandDatasourceJSONData(d.Get("json_data").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.CreateDatasourceInput{
+		UID:            d.Get("uid").(string),
+		Name:           d.Get("name").(string),
+		Type:           d.Get("type").(string),
+		URL:            d.Get("url").(string),
+		Access:         d.Get("access").(string),
+		IsDefault:      d.Get("is_default").(bool),
+		BasicAuth:      d.Get("basic_auth_enabled").(bool),
+		BasicAuthUser:  d.Get("basic_auth_username").(string),
+		JSONData:       jsonData,
+		SecureJSONData: expandStringMap(d.Get("secure_json_data").(map[string]interface{})),
+	}
+
+	resp, err := client.CreateDatasource(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(compositeID(stack, resp.UID))
+
+	return resourceDataSourceRead(ctx, d, m)
+}
+
+func resourceDataSourceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack, uid, err := splitCompositeID(d.Id(), "stack/uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	datasource, err := client.GetDatasource(ctx, uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if datasource == nil {
+		d.SetId("")
+		return diags
+	}
+
+	jsonData := ""
+	if len(datasource.JSONData) > 0 {
+		encoded, err := json.Marshal(datasource.JSONData)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		jsonData = string(encoded)
+	}
+
+	// Secure settings can't be read back, so only those still set in Grafana are kept
+	secureJSONData := make(map[string]interface{})
+	for k, v := range d.Get("secure_json_data").(map[string]interface{}) {
+		if datasource.SecureJSONFields[k] {
+			secureJSONData[k] = v
+		}
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("uid", datasource.UID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("datasource_id", datasource.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", datasource.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("type", datasource.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("url", datasource.URL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("access", datasource.Access); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("is_default", datasource.IsDefault); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("basic_auth_enabled", datasource.BasicAuth); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("basic_auth_username", datasource.BasicAuthUser); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("json_data", normalizeDatasourceJSONData(jsonData)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("secure_json_data", secureJSONData); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDataSourceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack, uid, err := splitCompositeID(d.Id(), "stack/uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	jsonData, err := expandDatasourceJSONData(d.Get("json_data").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Grafana keeps secure settings missing from the update, so removed ones are cleared explicitly
+	secureJSONData := expandStringMap(d.Get("secure_json_data").(map[string]interface{}))
+	old, _ := d.GetChange("secure_json_data")
+	for k := range old.(map[string]interface{}) {
+		if _, ok := secureJSONData[k]; !ok {
+			secureJSONData[k] = ""
+		}
+	}
+
+	req := &grafana.UpdateDatasourceInput{
+		Name:           d.Get("name").(string),
+		Type:           d.Get("type").(string),
+		URL:            d.Get("url").(string),
+		Access:         d.Get("access").(string),
+		IsDefault:      d.Get("is_default").(bool),
+		BasicAuth:      d.Get("basic_auth_enabled").(bool),
+		BasicAuthUser:  d.Get("basic_auth_username").(string),
+		JSONData:       jsonData,
+		SecureJSONData: secureJSONData,
+		UID:            uid,
+	}
+
+	_, err = client.UpdateDatasource(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDataSourceRead(ctx, d, m)
+}
+
+func resourceDataSourceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, uid, err := splitCompositeID(d.Id(), "stack/uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteDatasource(ctx, uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandDatasourceJSONData(jsonData string) (map[string]interface{}, error) {
+	if jsonData == "" {
+		return nil, nil
+	}
+
+	result := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Normalises the JSON data, so only actual changes to the settings result in a diff.
+func normalizeDatasourceJSONData(jsonData interface{}) string {
+	return normalizeJSON(jsonData, func(jsonData string) (interface{}, error) {
+		data, err := expandDatasourceJSONData(jsonData)
+		if err != nil || len(data) == 0 {
+			return nil, err
+		}
+
+		return data, nil
+	})
+}
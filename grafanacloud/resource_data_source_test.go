@@ -0,0 +1,185 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDataSource_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceConfig(resourceName, "https://prometheus.example.com", "15s"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceExists("grafanacloud_data_source.test", true),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "id", resourceName+"slug/"+resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "uid", resourceName),
+					resource.TestCheckResourceAttrSet("grafanacloud_data_source.test", "datasource_id"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "type", "prometheus"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "url", "https://prometheus.example.com"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "access", "proxy"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "is_default", "false"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "basic_auth_enabled", "true"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "basic_auth_username", "admin"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "json_data", `{"httpMethod":"POST","timeInterval":"15s"}`),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "secure_json_data.basicAuthPassword", "secret"),
+				),
+			},
+			{
+				Config: testAccDataSourceConfig(resourceName, "https://other-prometheus.example.com", "30s"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceExists("grafanacloud_data_source.test", true),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "url", "https://other-prometheus.example.com"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "json_data", `{"httpMethod":"POST","timeInterval":"30s"}`),
+				),
+			},
+			{
+				// Removed secure settings are cleared in Grafana
+				Config: testAccDataSourceConfigWithoutSecrets(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceExists("grafanacloud_data_source.test", false),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "basic_auth_enabled", "false"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "secure_json_data.%", "0"),
+				),
+			},
+			{
+				ResourceName:            "grafanacloud_data_source.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secure_json_data"},
+			},
+		},
+	})
+}
+
+func TestAccDataSource_Minimal(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceConfigMinimal(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceExists("grafanacloud_data_source.test", false),
+					resource.TestCheckResourceAttrSet("grafanacloud_data_source.test", "uid"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "type", "loki"),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "json_data", ""),
+					resource.TestCheckResourceAttr("grafanacloud_data_source.test", "basic_auth_enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckDataSourceExists(resourceName string, hasBasicAuthPassword bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		datasource, err := gc.GetDatasource(ctx, rs.Primary.Attributes["uid"])
+		if err != nil {
+			return err
+		}
+
+		if datasource == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		if datasource.SecureJSONFields["basicAuthPassword"] != hasBasicAuthPassword {
+			return fmt.Errorf("resource `%s` is expected to have a basic auth password set in Grafana: %t", resourceName, hasBasicAuthPassword)
+		}
+
+		return nil
+	}
+}
+
+func testAccDataSourceConfig(resourceName, url, timeInterval string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_data_source" "test" {
+  stack = grafanacloud_stack.test.slug
+  uid   = "%s"
+  name  = "%s"
+  type  = "prometheus"
+  url   = "%s"
+
+  basic_auth_enabled  = true
+  basic_auth_username = "admin"
+
+  json_data = jsonencode({
+    timeInterval = "%s"
+    httpMethod   = "POST"
+  })
+
+  secure_json_data = {
+    basicAuthPassword = "secret"
+  }
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, resourceName, url, timeInterval, resourceName, resourceName)
+}
+
+func testAccDataSourceConfigWithoutSecrets(resourceName string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_data_source" "test" {
+  stack = grafanacloud_stack.test.slug
+  uid   = "%s"
+  name  = "%s"
+  type  = "prometheus"
+  url   = "https://other-prometheus.example.com"
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, resourceName, resourceName, resourceName)
+}
+
+func testAccDataSourceConfigMinimal(resourceName string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_data_source" "test" {
+  stack = grafanacloud_stack.test.slug
+  name  = "%s"
+  type  = "loki"
+  url   = "https://loki.example.com"
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, resourceName, resourceName)
+}
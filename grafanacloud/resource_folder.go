@@ -0,0 +1,183 @@
+package grafanacloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+func resourceFolder() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single folder on a Grafana instance inside a Grafana Cloud stack. Notice that deleting a folder deletes all dashboards and alert rules stored in it as well.",
+		CreateContext: resourceFolderCreate,
+		ReadContext:   resourceFolderRead,
+		UpdateContext: resourceFolderUpdate,
+		DeleteContext: resourceFolderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(2),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the folder in Terraform, composed as `stack/uid`.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack to create the folder in.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Title of the folder.",
+			},
+			"uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "UID of the folder. Generated by Grafana if not set.",
+			},
+			"folder_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Numeric ID of the folder in Grafana.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Path of the folder relative to the Grafana instance URL.",
+			},
+		},
+	}
+}
+
+func resourceFolderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.CreateFolderInput{
+		UID:   d.Get("uid").(string),
+		Title: d.Get("title").(string),
+	}
+
+	resp, err := client.CreateFolder(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(compositeID(stack, resp.UID))
+
+	return resourceFolderRead(ctx, d, m)
+}
+
+func resourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack, uid, err := splitCompositeID(d.Id(), "stack/uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	folder, err := client.GetFolder(ctx, uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if folder == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("title", folder.Title); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("uid", folder.UID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("folder_id", folder.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("url", folder.URL); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceFolderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack, uid, err := splitCompositeID(d.Id(), "stack/uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.UpdateFolderInput{
+		Title:     d.Get("title").(string),
+		Overwrite: true,
+		UID:       uid,
+	}
+
+	_, err = client.UpdateFolder(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceFolderRead(ctx, d, m)
+}
+
+func resourceFolderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, uid, err := splitCompositeID(d.Id(), "stack/uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteFolder(ctx, uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,243 @@
+package grafanacloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+var (
+	folderPermissionRoles      = []string{"Viewer", "Editor", "Admin"}
+	folderPermissionLevelNames = []string{"View", "Edit", "Admin"}
+	folderPermissionLevels     = map[string]int{
+		"View":  grafana.FolderPermissionView,
+		"Edit":  grafana.FolderPermissionEdit,
+		"Admin": grafana.FolderPermissionAdmin,
+	}
+)
+
+func resourceFolderPermission() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages all permissions of a single folder on a Grafana instance inside a Grafana Cloud stack. Permissions not configured here are removed from the folder, and destroying this resource removes all permissions from the folder.",
+		CreateContext: resourceFolderPermissionUpdate,
+		ReadContext:   resourceFolderPermissionRead,
+		UpdateContext: resourceFolderPermissionUpdate,
+		DeleteContext: resourceFolderPermissionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(2),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the folder permissions in Terraform, composed as `stack/folder_uid`.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack the folder belongs to.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"folder_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UID of the folder to manage the permissions of.",
+			},
+			"permission": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Permissions granted on the folder. Each permission is granted to exactly one of `role`, `team_id` or `user_id`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  fmt.Sprintf("Role to grant the permission to. Might be one of %s.", folderPermissionRoles),
+							ValidateFunc: validation.StringInSlice(folderPermissionRoles, false),
+						},
+						"team_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "ID of the team to grant the permission to.",
+						},
+						"user_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "ID of the user to grant the permission to.",
+						},
+						"permission": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  fmt.Sprintf("Permission to grant. Might be one of %s.", folderPermissionLevelNames),
+							ValidateFunc: validation.StringInSlice(folderPermissionLevelNames, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceFolderPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack, folderUID, err := splitCompositeID(d.Id(), "stack/folder_uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	folder, err := client.GetFolder(ctx, folderUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if folder == nil {
+		d.SetId("")
+		return diags
+	}
+
+	permissions, err := client.ListFolderPermissions(ctx, folderUID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("folder_uid", folderUID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("permission", flattenFolderPermissions(permissions.Items)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// Grafana replaces all permissions of a folder at once, so creating and updating is the same.
+func resourceFolderPermissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	permissions, err := expandFolderPermissions(d.Get("permission").(*schema.Set).List())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.UpdateFolderPermissionsInput{
+		Items:     permissions,
+		FolderUID: d.Get("folder_uid").(string),
+	}
+
+	err = client.UpdateFolderPermissions(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(compositeID(stack, req.FolderUID))
+
+	return resourceFolderPermissionRead(ctx, d, m)
+}
+
+func resourceFolderPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, folderUID, err := splitCompositeID(d.Id(), "stack/folder_uid")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.UpdateFolderPermissionsInput{
+		Items:     make([]*grafana.FolderPermission, 0),
+		FolderUID: folderUID,
+	}
+
+	err = client.UpdateFolderPermissions(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandFolderPermissions(permissions []interface{}) ([]*grafana.FolderPermission, error) {
+	result := make([]*grafana.FolderPermission, 0, len(permissions))
+
+	for _, p := range permissions {
+		permission := p.(map[string]interface{})
+
+		item := &grafana.FolderPermission{
+			Role:       permission["role"].(string),
+			TeamID:     permission["team_id"].(int),
+			UserID:     permission["user_id"].(int),
+			Permission: folderPermissionLevels[permission["permission"].(string)],
+		}
+
+		grantees := 0
+		for _, set := range []bool{item.Role != "", item.TeamID != 0, item.UserID != 0} {
+			if set {
+				grantees++
+			}
+		}
+
+		if grantees != 1 {
+			return nil, fmt.Errorf("folder permissions must be granted to exactly one of `role`, `team_id` or `user_id`")
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+func flattenFolderPermissions(permissions []*grafana.FolderPermission) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(permissions))
+
+	for _, permission := range permissions {
+		level := ""
+		for name, value := range folderPermissionLevels {
+			if value == permission.Permission {
+				level = name
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"role":       permission.Role,
+			"team_id":    permission.TeamID,
+			"user_id":    permission.UserID,
+			"permission": level,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,138 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccFolderPermission_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderPermissionConfig(resourceName, `
+  permission {
+    role       = "Viewer"
+    permission = "View"
+  }
+
+  permission {
+    team_id    = 7
+    permission = "Admin"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderPermissionCount("grafanacloud_folder_permission.test", 2),
+					resource.TestCheckResourceAttr("grafanacloud_folder_permission.test", "id", resourceName+"slug/"+resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_folder_permission.test", "permission.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("grafanacloud_folder_permission.test", "permission.*", map[string]string{
+						"role":       "Viewer",
+						"permission": "View",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("grafanacloud_folder_permission.test", "permission.*", map[string]string{
+						"team_id":    "7",
+						"permission": "Admin",
+					}),
+				),
+			},
+			{
+				Config: testAccFolderPermissionConfig(resourceName, `
+  permission {
+    role       = "Editor"
+    permission = "Edit"
+  }
+
+  permission {
+    role       = "Admin"
+    permission = "Admin"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderPermissionCount("grafanacloud_folder_permission.test", 2),
+					resource.TestCheckResourceAttr("grafanacloud_folder_permission.test", "permission.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("grafanacloud_folder_permission.test", "permission.*", map[string]string{
+						"role":       "Editor",
+						"permission": "Edit",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("grafanacloud_folder_permission.test", "permission.*", map[string]string{
+						"role":       "Admin",
+						"permission": "Admin",
+					}),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_folder_permission.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccFolderPermission_InvalidGrantee(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderPermissionConfig(resourceName, `
+  permission {
+    role       = "Viewer"
+    user_id    = 3
+    permission = "View"
+  }
+`),
+				ExpectError: regexp.MustCompile("exactly one of `role`, `team_id` or `user_id`"),
+			},
+		},
+	})
+}
+
+func testAccCheckFolderPermissionCount(resourceName string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		permissions, err := gc.ListFolderPermissions(ctx, rs.Primary.Attributes["folder_uid"])
+		if err != nil {
+			return err
+		}
+
+		if len(permissions.Items) != count {
+			return fmt.Errorf("resource `%s` has %d permissions in Grafana, expected %d", resourceName, len(permissions.Items), count)
+		}
+
+		return nil
+	}
+}
+
+func testAccFolderPermissionConfig(resourceName, permissions string) string {
+	return testAccFolderConfig(resourceName, "Team A") + fmt.Sprintf(`
+resource "grafanacloud_folder_permission" "test" {
+  stack      = grafanacloud_stack.test.slug
+  folder_uid = grafanacloud_folder.test.uid
+%s
+}
+`, permissions)
+}
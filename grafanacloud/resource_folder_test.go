@@ -0,0 +1,185 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccFolder_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderConfig(resourceName, "Team A"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("grafanacloud_folder.test"),
+					resource.TestCheckResourceAttr("grafanacloud_folder.test", "id", resourceName+"slug/"+resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_folder.test", "uid", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_folder.test", "title", "Team A"),
+					resource.TestCheckResourceAttrSet("grafanacloud_folder.test", "folder_id"),
+					resource.TestCheckResourceAttrSet("grafanacloud_folder.test", "url"),
+				),
+			},
+			{
+				Config: testAccFolderConfig(resourceName, "Team B"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("grafanacloud_folder.test"),
+					resource.TestCheckResourceAttr("grafanacloud_folder.test", "uid", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_folder.test", "title", "Team B"),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_folder.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccFolder_Dashboard(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderConfigDashboard(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("grafanacloud_folder.test"),
+					resource.TestCheckResourceAttrPair("grafanacloud_dashboard.test", "folder", "grafanacloud_folder.test", "uid"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFolder_Organisation(t *testing.T) {
+	org := os.Getenv(EnvOtherOrganisation)
+	if org == "" {
+		t.Skipf("%s must be set to test managing folders of stacks in other organisations", EnvOtherOrganisation)
+	}
+
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFolderConfigOrganisation(resourceName, org, "Team A"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("grafanacloud_folder.test"),
+					resource.TestCheckResourceAttr("grafanacloud_folder.test", "organisation", org),
+					resource.TestCheckResourceAttr("grafanacloud_folder.test", "title", "Team A"),
+				),
+			},
+			{
+				Config: testAccFolderConfigOrganisation(resourceName, org, "Team B"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckFolderExists("grafanacloud_folder.test"),
+					resource.TestCheckResourceAttr("grafanacloud_folder.test", "title", "Team B"),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_folder.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%s/%sslug/%s", org, resourceName, resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckFolderExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, testAccOrganisation(rs), rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		folder, err := gc.GetFolder(ctx, rs.Primary.Attributes["uid"])
+		if err != nil {
+			return err
+		}
+
+		if folder == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		if folder.Title != rs.Primary.Attributes["title"] {
+			return fmt.Errorf("resource `%s` has title `%s` in Grafana, expected `%s`", resourceName, folder.Title, rs.Primary.Attributes["title"])
+		}
+
+		return nil
+	}
+}
+
+func testAccFolderConfig(resourceName, title string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_folder" "test" {
+  stack = grafanacloud_stack.test.slug
+  uid   = "%s"
+  title = "%s"
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, title, resourceName, resourceName)
+}
+
+func testAccFolderConfigOrganisation(resourceName, org, title string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_folder" "test" {
+  stack        = grafanacloud_stack.test.slug
+  organisation = grafanacloud_stack.test.organisation
+  uid          = "%[1]s"
+  title        = "%[3]s"
+}
+
+resource "grafanacloud_stack" "test" {
+  name         = "%[1]s"
+  slug         = "%[1]sslug"
+  organisation = "%[2]s"
+  delete_protection = false
+}
+`, resourceName, org, title)
+}
+
+func testAccFolderConfigDashboard(resourceName string) string {
+	return testAccFolderConfig(resourceName, "Team A") + `
+resource "grafanacloud_dashboard" "test" {
+  stack  = grafanacloud_stack.test.slug
+  folder = grafanacloud_folder.test.uid
+  config_json = jsonencode({
+    title  = "Team A overview"
+    panels = []
+  })
+}
+`
+}
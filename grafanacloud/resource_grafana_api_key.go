@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
 	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
 )
 
@@ -17,10 +19,15 @@ var (
 
 func resourceGrafanaApiKey() *schema.Resource {
 	return &schema.Resource{
-		Description:   "Manages a single API key on a Grafana instance inside a Grafana Cloud stack. Notice that the key value will be stored in Terraform state, so make sure to manage your Terraform state safely (see https://www.terraform.io/docs/language/state/sensitive-data.html).",
+		Description:   "Manages a single API key on a Grafana instance inside a Grafana Cloud stack. Notice that the key value will be stored in Terraform state, so make sure to manage your Terraform state safely (see https://www.terraform.io/docs/language/state/sensitive-data.html).\n\nGrafana API keys are deprecated in favour of service accounts. Existing keys can be migrated to a service account without changing the key value by setting `migrate_to_service_account` to `true`.",
 		CreateContext: resourceApiKeyCreate,
 		ReadContext:   resourceApiKeyRead,
+		UpdateContext: resourceApiKeyUpdate,
 		DeleteContext: resourceApiKeyDelete,
+		CustomizeDiff: resourceApiKeyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceApiKeyImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
@@ -39,6 +46,7 @@ func resourceGrafanaApiKey() *schema.Resource {
 				ForceNew:    true,
 				Description: "Grafana Cloud stack to create this API key in.",
 			},
+			"organisation": resourceOrganisationSchema(),
 			"role": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -70,6 +78,22 @@ func resourceGrafanaApiKey() *schema.Resource {
 				Sensitive:   true,
 				Description: "The generated API key.",
 			},
+			"migrate_to_service_account": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether or not to migrate the API key to a service account. The API key becomes a token of a new service account named after the key, and keeps working as before. Migrations can't be reverted, so setting this back to `false` recreates the API key.",
+			},
+			"service_account_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the service account the API key was migrated to.",
+			},
+			"service_account_token_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the service account token the API key was migrated to.",
+			},
 		},
 	}
 }
@@ -96,6 +120,10 @@ func resourceApiKeyCreate(ctx context.Context, d *schema.ResourceData, m interfa
 	d.Set("key", resp.Key)
 	d.SetId(strconv.Itoa(resp.ID))
 
+	if d.Get("migrate_to_service_account").(bool) {
+		return resourceApiKeyUpdate(ctx, d, m)
+	}
+
 	return resourceApiKeyRead(ctx, d, m)
 }
 
@@ -103,14 +131,14 @@ func resourceApiKeyRead(ctx context.Context, d *schema.ResourceData, m interface
 	var diags diag.Diagnostics
 	p := m.(*Provider)
 
-	stack := d.Get("stack").(string)
-	client, cleanup, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, stack)
-	if err != nil {
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if cleanup != nil {
-		defer cleanup()
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	id, err := strconv.Atoi(d.Id())
@@ -118,6 +146,11 @@ func resourceApiKeyRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
+	// Migrated API keys aren't listed as API keys anymore
+	if serviceAccountID := d.Get("service_account_id").(int); serviceAccountID != 0 {
+		return resourceApiKeyReadMigrated(ctx, d, client, serviceAccountID, id)
+	}
+
 	apiKeys, err := client.ListAPIKeys(ctx, true)
 	if err != nil {
 		return diag.FromErr(err)
@@ -125,8 +158,31 @@ func resourceApiKeyRead(ctx context.Context, d *schema.ResourceData, m interface
 
 	apiKey := apiKeys.FindByID(id)
 	if apiKey == nil {
-		d.SetId("")
-		return diags
+		// The API key might have been migrated without recording its service account, because the lookup
+		// after the migration failed. Searching costs a request per service account, so keys which weren't
+		// migrated by the provider are considered deleted, and migrated keys are imported with their
+		// service account.
+		name := d.Get("name").(string)
+		if !d.Get("migrate_to_service_account").(bool) || name == "" {
+			d.SetId("")
+			return diags
+		}
+
+		serviceAccount, err := findMigratedApiKey(ctx, client, id, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if serviceAccount == nil {
+			d.SetId("")
+			return diags
+		}
+
+		if err := d.Set("service_account_id", serviceAccount.ID); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return resourceApiKeyReadMigrated(ctx, d, client, serviceAccount.ID, id)
 	}
 
 	expired, err := apiKey.IsExpired()
@@ -150,21 +206,154 @@ func resourceApiKeyRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("migrate_to_service_account", false); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
-func resourceApiKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceApiKeyReadMigrated(ctx context.Context, d *schema.ResourceData, client *grafana.Client, serviceAccountID, tokenID int) diag.Diagnostics {
 	var diags diag.Diagnostics
+
+	serviceAccount, err := client.GetServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if serviceAccount == nil {
+		d.SetId("")
+		return diags
+	}
+
+	tokens, err := client.ListServiceAccountTokens(ctx, serviceAccountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	token := tokens.FindByID(tokenID)
+	if token == nil {
+		d.SetId("")
+		return diags
+	}
+
+	expired, err := token.IsExpired()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", token.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("role", serviceAccount.Role); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("expiration", token.Expiration); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("is_expired", expired); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("migrate_to_service_account", true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("service_account_token_id", token.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// The only attribute which can be updated in place is `migrate_to_service_account`, and only
+// from `false` to `true`. Everything else forces a new API key.
+func resourceApiKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	p := m.(*Provider)
 
+	if !d.Get("migrate_to_service_account").(bool) || d.Get("service_account_id").(int) != 0 {
+		return resourceApiKeyRead(ctx, d, m)
+	}
+
 	stack := d.Get("stack").(string)
-	client, cleanup, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, stack)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := client.MigrateAPIKey(ctx, id); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Record the migration before looking up the service account. If the lookup fails, the key isn't
+	// listed as API key anymore, and Read has to find its service account instead of dropping it.
+	if err := d.Set("migrate_to_service_account", true); err != nil {
+		return diag.FromErr(err)
+	}
+
+	serviceAccount, err := findMigratedApiKey(ctx, client, id, d.Get("name").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if cleanup != nil {
-		defer cleanup()
+	if serviceAccount == nil {
+		return diag.Errorf("API key `%d` was migrated, but its service account `%s` couldn't be found", id, d.Get("name").(string))
+	}
+
+	if err := d.Set("service_account_id", serviceAccount.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceApiKeyRead(ctx, d, m)
+}
+
+// Looks up the service account of a migrated API key. Grafana names it after the API key, and
+// the token of the service account keeps the ID of the API key. Returns nil if there's none.
+func findMigratedApiKey(ctx context.Context, client *grafana.Client, id int, name string) (*grafana.ServiceAccount, error) {
+	serviceAccounts, err := client.SearchServiceAccounts(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, serviceAccount := range serviceAccounts.Items {
+		tokens, err := client.ListServiceAccountTokens(ctx, serviceAccount.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if tokens.FindByID(id) != nil {
+			return serviceAccount, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func resourceApiKeyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Migrated API keys can't be turned back into plain API keys
+	if d.Id() != "" && d.HasChange("migrate_to_service_account") && !d.Get("migrate_to_service_account").(bool) {
+		return d.ForceNew("migrate_to_service_account")
+	}
+
+	return nil
+}
+
+func resourceApiKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	id, err := strconv.Atoi(d.Id())
@@ -172,7 +361,13 @@ func resourceApiKeyDelete(ctx context.Context, d *schema.ResourceData, m interfa
 		return diag.FromErr(err)
 	}
 
-	err = client.DeleteAPIKey(ctx, id)
+	// The service account was created just for the migrated API key, so it's deleted along with it
+	if serviceAccountID := d.Get("service_account_id").(int); serviceAccountID != 0 {
+		err = client.DeleteServiceAccount(ctx, serviceAccountID)
+	} else {
+		err = client.DeleteAPIKey(ctx, id)
+	}
+
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -180,3 +375,53 @@ func resourceApiKeyDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	d.SetId("")
 	return diags
 }
+
+// Grafana API key IDs are only unique within a stack, so they're imported using
+// the composite ID `stack/id`.
+// API keys migrated to a service account aren't listed as API keys anymore, so they're imported together
+// with the ID of their service account. Like other resources, keys of stacks in other organisations than
+// the one of the provider are imported with the organisation as prefix. Stack slugs start with a letter,
+// so IDs of API keys can't be mistaken for them.
+func resourceApiKeyImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	prefixed := len(parts) > 3
+	if len(parts) == 3 {
+		_, err := strconv.Atoi(parts[1])
+		prefixed = err != nil
+	}
+
+	if err := setImportOrganisation(d, prefixed); err != nil {
+		return nil, err
+	}
+
+	format := "stack/id or stack/id/service_account_id"
+	stack, id, err := splitCompositeID(d.Id(), format)
+	if err != nil {
+		return nil, err
+	}
+
+	if keyID, serviceAccountID, err := splitCompositeID(id, format); err == nil {
+		id = keyID
+
+		serviceAccount, err := strconv.Atoi(serviceAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service account ID `%s` in import ID, expected a number: %v", serviceAccountID, err)
+		}
+
+		if err := d.Set("service_account_id", serviceAccount); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := strconv.Atoi(id); err != nil {
+		return nil, fmt.Errorf("invalid API key ID `%s` in import ID, expected a number: %v", id, err)
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
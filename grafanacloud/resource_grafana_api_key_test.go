@@ -3,6 +3,7 @@ package grafanacloud_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"regexp"
 	"strconv"
 	"testing"
@@ -73,12 +74,49 @@ func TestAccGrafanaApiKey_Basic(t *testing.T) {
 							resource.TestCheckNoResourceAttr("grafanacloud_grafana_api_key.test", "seconds_to_live"),
 						),
 					},
+					{
+						ResourceName:            "grafanacloud_grafana_api_key.test",
+						ImportState:             true,
+						ImportStateIdFunc:       testAccGrafanaAPIKeyImportID("grafanacloud_grafana_api_key.test"),
+						ImportStateVerify:       true,
+						ImportStateVerifyIgnore: []string{"key"},
+					},
 				},
 			})
 		})
 	}
 }
 
+func TestAccGrafanaApiKey_Organisation(t *testing.T) {
+	org := os.Getenv(EnvOtherOrganisation)
+	if org == "" {
+		t.Skipf("%s must be set to test managing API keys of stacks in other organisations", EnvOtherOrganisation)
+	}
+
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGrafanaAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrafanaAPIKeyConfigOrganisation(resourceName, org),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGrafanaAPIKeyExists("grafanacloud_grafana_api_key.test"),
+					resource.TestCheckResourceAttr("grafanacloud_grafana_api_key.test", "organisation", org),
+				),
+			},
+			{
+				ResourceName:            "grafanacloud_grafana_api_key.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccGrafanaAPIKeyOrganisationImportID("grafanacloud_grafana_api_key.test"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+		},
+	})
+}
+
 func TestAccGrafanaApiKey_Expiring(t *testing.T) {
 	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 
@@ -137,6 +175,136 @@ func TestAccGrafanaApiKey_Expired(t *testing.T) {
 	})
 }
 
+func TestAccGrafanaApiKey_MigrateToServiceAccount(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	var keyID, key string
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGrafanaAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGrafanaAPIKeyConfigMigrated(resourceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGrafanaAPIKeyExists("grafanacloud_grafana_api_key.test"),
+					testAccStoreGrafanaAPIKey("grafanacloud_grafana_api_key.test", &keyID, &key),
+					resource.TestCheckResourceAttr("grafanacloud_grafana_api_key.test", "migrate_to_service_account", "false"),
+					resource.TestCheckNoResourceAttr("grafanacloud_grafana_api_key.test", "service_account_id"),
+				),
+			},
+			{
+				Config: testAccGrafanaAPIKeyConfigMigrated(resourceName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGrafanaAPIKeyMigrated("grafanacloud_grafana_api_key.test"),
+					testAccCheckGrafanaAPIKeyUnchanged("grafanacloud_grafana_api_key.test", &keyID, &key),
+					resource.TestCheckResourceAttr("grafanacloud_grafana_api_key.test", "migrate_to_service_account", "true"),
+					resource.TestCheckResourceAttr("grafanacloud_grafana_api_key.test", "name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_grafana_api_key.test", "role", "Viewer"),
+					resource.TestCheckResourceAttrPair("grafanacloud_grafana_api_key.test", "service_account_token_id", "grafanacloud_grafana_api_key.test", "id"),
+				),
+			},
+			{
+				// Migrated API keys aren't searched for among all service accounts
+				ResourceName:      "grafanacloud_grafana_api_key.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccGrafanaAPIKeyImportID("grafanacloud_grafana_api_key.test"),
+				ExpectError:       regexp.MustCompile(`non-existent`),
+			},
+			{
+				// Migrated API keys are found by the token of their service account
+				ResourceName:            "grafanacloud_grafana_api_key.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccGrafanaAPIKeyMigratedImportID("grafanacloud_grafana_api_key.test"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+			{
+				// Reverting the migration recreates the API key
+				Config: testAccGrafanaAPIKeyConfigMigrated(resourceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGrafanaAPIKeyExists("grafanacloud_grafana_api_key.test"),
+					resource.TestCheckResourceAttr("grafanacloud_grafana_api_key.test", "migrate_to_service_account", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccStoreGrafanaAPIKey(resourceName string, id, key *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		*id = rs.Primary.ID
+		*key = rs.Primary.Attributes["key"]
+		return nil
+	}
+}
+
+func testAccCheckGrafanaAPIKeyUnchanged(resourceName string, id, key *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID != *id || rs.Primary.Attributes["key"] != *key {
+			return fmt.Errorf("resource `%s` was recreated", resourceName)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGrafanaAPIKeyMigrated(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		apiKeys, err := gc.ListAPIKeys(ctx, true)
+		if err != nil {
+			return err
+		}
+
+		if apiKeys.FindByID(id) != nil {
+			return fmt.Errorf("resource `%s` is still an API key", resourceName)
+		}
+
+		serviceAccountID, err := strconv.Atoi(rs.Primary.Attributes["service_account_id"])
+		if err != nil {
+			return err
+		}
+
+		tokens, err := gc.ListServiceAccountTokens(ctx, serviceAccountID)
+		if err != nil {
+			return err
+		}
+
+		if tokens.FindByID(id) == nil {
+			return fmt.Errorf("resource `%s` not found as service account token via API", resourceName)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckGrafanaAPIKeyExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		ctx := context.Background()
@@ -151,15 +319,11 @@ func testAccCheckGrafanaAPIKeyExists(resourceName string) resource.TestCheckFunc
 		}
 
 		p := getProvider(testAccProvider)
-		gc, cleanup, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, testAccOrganisation(rs), rs.Primary.Attributes["stack"])
 		if err != nil {
 			return err
 		}
 
-		if cleanup != nil {
-			defer cleanup()
-		}
-
 		res, err := gc.ListAPIKeys(ctx, true)
 		if err != nil {
 			return err
@@ -179,6 +343,39 @@ func testAccCheckGrafanaAPIKeyExists(resourceName string) resource.TestCheckFunc
 	}
 }
 
+func testAccGrafanaAPIKeyImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["stack"], rs.Primary.ID), nil
+	}
+}
+
+func testAccGrafanaAPIKeyOrganisationImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["organisation"], rs.Primary.Attributes["stack"], rs.Primary.ID), nil
+	}
+}
+
+func testAccGrafanaAPIKeyMigratedImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["stack"], rs.Primary.ID, rs.Primary.Attributes["service_account_id"]), nil
+	}
+}
+
 func testAccCheckGrafanaAPIKeyDestroy(s *terraform.State) error {
 	ctx := context.Background()
 	p := getProvider(testAccProvider)
@@ -213,10 +410,29 @@ resource "grafanacloud_grafana_api_key" "test" {
 resource "grafanacloud_stack" "test" {
   name = "dummy-stack"
 	slug = "dummystack"
+	delete_protection = false
 }
 `, resourceName, role)
 }
 
+func testAccGrafanaAPIKeyConfigOrganisation(resourceName, org string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_grafana_api_key" "test" {
+  name         = "%[1]s"
+  role         = "Viewer"
+  stack        = grafanacloud_stack.test.slug
+  organisation = grafanacloud_stack.test.organisation
+}
+
+resource "grafanacloud_stack" "test" {
+  name         = "%[1]s"
+  slug         = "%[1]sslug"
+  organisation = "%[2]s"
+  delete_protection = false
+}
+`, resourceName, org)
+}
+
 func testAccGrafanaAPIKeyConfigExpiring(resourceName string, secondsToLive int) string {
 	return fmt.Sprintf(`
 resource "grafanacloud_grafana_api_key" "test" {
@@ -229,10 +445,28 @@ resource "grafanacloud_grafana_api_key" "test" {
 resource "grafanacloud_stack" "test" {
   name = "dummy-stack"
 	slug = "dummystack"
+	delete_protection = false
 }
 `, resourceName, secondsToLive)
 }
 
+func testAccGrafanaAPIKeyConfigMigrated(resourceName string, migrate bool) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_grafana_api_key" "test" {
+  name = "%s"
+  role = "Viewer"
+	stack = grafanacloud_stack.test.slug
+	migrate_to_service_account = %t
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "dummy-stack"
+	slug = "dummystack"
+	delete_protection = false
+}
+`, resourceName, migrate)
+}
+
 func testAccSleep(d time.Duration) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		time.Sleep(d)
@@ -0,0 +1,41 @@
+package grafanacloud
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+var (
+	lokiStreamSelectorRegex = regexp.MustCompile(`\{[^{}]*\}`)
+)
+
+func resourceLokiRuleNamespace() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single namespace of recording and alerting rules in the hosted Loki instance of a Grafana Cloud stack. The rules are LogQL metric queries evaluated by the ruler of the instance, independently of Grafana Alerting.",
+		CreateContext: ruleNamespaceCreate((*portal.Client).GetLokiRulerClient),
+		ReadContext:   ruleNamespaceRead((*portal.Client).GetLokiRulerClient),
+		UpdateContext: ruleNamespaceUpdate((*portal.Client).GetLokiRulerClient),
+		DeleteContext: ruleNamespaceDelete((*portal.Client).GetLokiRulerClient),
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(2),
+		},
+		Schema: ruleNamespaceSchema("Loki", validateLokiRuleExpr),
+	}
+}
+
+// Rules must be metric queries over log streams, as log queries don't result in samples to evaluate
+func validateLokiRuleExpr(expr string) error {
+	if !lokiStreamSelectorRegex.MatchString(expr) {
+		return fmt.Errorf("must select log streams, e.g. `{app=\"foo\"}`")
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(expr), "{") {
+		return fmt.Errorf("must be a metric query, e.g. `sum(rate({app=\"foo\"}[5m]))`, not a log query")
+	}
+
+	return nil
+}
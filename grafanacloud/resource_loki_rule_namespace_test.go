@@ -0,0 +1,173 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccLokiRuleNamespace_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLokiRuleNamespaceConfig(resourceName, `
+    groups:
+      - name: recording
+        interval: 1m
+        rules:
+          - record: job:log_errors:rate5m
+            expr: sum by (job) (rate({job="api"} |= "error" [5m]))
+      - name: alerting
+        rules:
+          - alert: HighLogErrorRate
+            expr: sum by (job) (rate({job="api"} |= "error" [5m])) > 10
+            for: 10m
+            labels:
+              severity: critical
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLokiRuleNamespaceExists("grafanacloud_loki_rule_namespace.test", 2),
+					resource.TestCheckResourceAttr("grafanacloud_loki_rule_namespace.test", "id", resourceName+"slug/"+resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_loki_rule_namespace.test", "name", resourceName),
+					resource.TestMatchResourceAttr("grafanacloud_loki_rule_namespace.test", "content", regexp.MustCompile(`(?s)^groups:\n  - name: alerting\n.*  - name: recording\n`)),
+				),
+			},
+			{
+				// Only the formatting and order of groups changed, which must not result in a diff
+				Config: testAccLokiRuleNamespaceConfig(resourceName, `
+    groups:
+    - name: alerting
+      rules:
+      - alert: HighLogErrorRate
+        expr:   sum by (job) (rate({job="api"} |= "error" [5m])) > 10
+        for: 10m
+        labels: {severity: critical}
+    - name: recording
+      interval: 1m
+      rules:
+      - {record: "job:log_errors:rate5m", expr: "sum by (job) (rate({job=\"api\"} |= \"error\" [5m]))"}
+`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccLokiRuleNamespaceConfig(resourceName, `
+    groups:
+      - name: recording
+        interval: 2m
+        rules:
+          - record: job:log_errors:rate5m
+            expr: sum by (job) (rate({job="api"} |= "error" [5m]))
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLokiRuleNamespaceExists("grafanacloud_loki_rule_namespace.test", 1),
+					resource.TestMatchResourceAttr("grafanacloud_loki_rule_namespace.test", "content", regexp.MustCompile(`interval: 2m`)),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_loki_rule_namespace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccLokiRuleNamespace_Invalid(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	invalid := map[string]string{
+		"no rules": `
+    groups:
+      - name: test
+        rules: []
+`,
+		"no stream selector": `
+    groups:
+      - name: test
+        rules:
+          - alert: Test
+            expr: up == 0
+`,
+		"log query": `
+    groups:
+      - name: test
+        rules:
+          - alert: Test
+            expr: '{job="api"} |= "error"'
+`,
+	}
+
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccLokiRuleNamespaceConfig(resourceName, content),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(`content`),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccCheckLokiRuleNamespaceExists(resourceName string, groups int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		client, err := p.Client.GetLokiRulerClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.ListRuleGroups(ctx, rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+
+		if len(resp) != groups {
+			return fmt.Errorf("resource `%s` has %d rule groups in the ruler, expected %d", resourceName, len(resp), groups)
+		}
+
+		return nil
+	}
+}
+
+func testAccLokiRuleNamespaceConfig(resourceName, content string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_loki_rule_namespace" "test" {
+  stack   = grafanacloud_stack.test.slug
+  name    = "%s"
+  content = <<-EOT
+%s
+  EOT
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, content, resourceName, resourceName)
+}
@@ -0,0 +1,284 @@
+package grafanacloud
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+var (
+	muteTimingTimeRegex    = regexp.MustCompile(`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`)
+	muteTimingWeekdayRegex = regexp.MustCompile(`^(monday|tuesday|wednesday|thursday|friday|saturday|sunday)(:(monday|tuesday|wednesday|thursday|friday|saturday|sunday))?$`)
+)
+
+func resourceMuteTiming() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single Grafana Alerting mute timing on a Grafana instance inside a Grafana Cloud stack. Notification policies referencing the mute timing don't send notifications during any of its intervals.",
+		CreateContext: resourceMuteTimingCreate,
+		ReadContext:   resourceMuteTimingRead,
+		UpdateContext: resourceMuteTimingUpdate,
+		DeleteContext: resourceMuteTimingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(2),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the mute timing in Terraform, composed as `stack/name`.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack to create the mute timing in.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the mute timing, referenced by notification policies.",
+			},
+			"interval": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Time intervals during which notifications are muted. A time must match all fields set on an interval to be in it. Notifications are always muted if no intervals are set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"times": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Ranges of times of day.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Description:  "Start of the range as `HH:MM`, inclusive.",
+										ValidateFunc: validation.StringMatch(muteTimingTimeRegex, "must be a time of day as `HH:MM`"),
+									},
+									"end": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Description:  "End of the range as `HH:MM`, exclusive.",
+										ValidateFunc: validation.StringMatch(muteTimingTimeRegex, "must be a time of day as `HH:MM`"),
+									},
+								},
+							},
+						},
+						"weekdays": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringMatch(muteTimingWeekdayRegex, "must be a lowercase weekday or a range of weekdays, e.g. `monday:friday`"),
+							},
+							Description: "Days of the week or ranges of them, e.g. `saturday` or `monday:friday`.",
+						},
+						"days_of_month": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Days of the month or ranges of them, e.g. `1` or `1:5`. Negative days count from the end of the month, e.g. `-1` for its last day.",
+						},
+						"months": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Months or ranges of them, either by name or number, e.g. `december` or `1:3`.",
+						},
+						"years": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Years or ranges of them, e.g. `2030` or `2030:2035`.",
+						},
+						"location": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Time zone of the interval as IANA name, e.g. `Europe/London`. Defaults to UTC.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceMuteTimingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.MuteTiming{
+		Name:          d.Get("name").(string),
+		TimeIntervals: expandTimeIntervals(d.Get("interval").([]interface{})),
+	}
+
+	resp, err := client.CreateMuteTiming(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(compositeID(stack, resp.Name))
+
+	return resourceMuteTimingRead(ctx, d, m)
+}
+
+func resourceMuteTimingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack, name, err := splitCompositeID(d.Id(), "stack/name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	muteTiming, err := client.GetMuteTiming(ctx, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if muteTiming == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", muteTiming.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("interval", flattenTimeIntervals(muteTiming.TimeIntervals)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceMuteTimingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack, name, err := splitCompositeID(d.Id(), "stack/name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.MuteTiming{
+		Name:          name,
+		TimeIntervals: expandTimeIntervals(d.Get("interval").([]interface{})),
+	}
+
+	_, err = client.UpdateMuteTiming(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceMuteTimingRead(ctx, d, m)
+}
+
+func resourceMuteTimingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, name, err := splitCompositeID(d.Id(), "stack/name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteMuteTiming(ctx, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandTimeIntervals(intervals []interface{}) []*grafana.TimeInterval {
+	result := make([]*grafana.TimeInterval, 0, len(intervals))
+
+	for _, i := range intervals {
+		// Intervals without any fields set are decoded as nil
+		interval, _ := i.(map[string]interface{})
+		if interval == nil {
+			result = append(result, &grafana.TimeInterval{})
+			continue
+		}
+
+		times := make([]*grafana.TimeRange, 0)
+		for _, t := range interval["times"].([]interface{}) {
+			times = append(times, &grafana.TimeRange{
+				StartTime: t.(map[string]interface{})["start"].(string),
+				EndTime:   t.(map[string]interface{})["end"].(string),
+			})
+		}
+
+		result = append(result, &grafana.TimeInterval{
+			Times:       times,
+			Weekdays:    expandStringList(interval["weekdays"].([]interface{})),
+			DaysOfMonth: expandStringList(interval["days_of_month"].([]interface{})),
+			Months:      expandStringList(interval["months"].([]interface{})),
+			Years:       expandStringList(interval["years"].([]interface{})),
+			Location:    interval["location"].(string),
+		})
+	}
+
+	return result
+}
+
+func flattenTimeIntervals(intervals []*grafana.TimeInterval) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(intervals))
+
+	for _, interval := range intervals {
+		times := make([]map[string]interface{}, 0, len(interval.Times))
+		for _, t := range interval.Times {
+			times = append(times, map[string]interface{}{
+				"start": t.StartTime,
+				"end":   t.EndTime,
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"times":         times,
+			"weekdays":      interval.Weekdays,
+			"days_of_month": interval.DaysOfMonth,
+			"months":        interval.Months,
+			"years":         interval.Years,
+			"location":      interval.Location,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,111 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMuteTiming_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMuteTimingConfig(resourceName, "monday:friday"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMuteTimingExists("grafanacloud_mute_timing.test"),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "id", resourceName+"slug/"+resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "interval.#", "2"),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "interval.0.times.0.start", "18:00"),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "interval.0.times.0.end", "24:00"),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "interval.0.weekdays.0", "monday:friday"),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "interval.0.location", "Europe/London"),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "interval.1.days_of_month.0", "25"),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "interval.1.months.0", "december"),
+				),
+			},
+			{
+				Config: testAccMuteTimingConfig(resourceName, "monday:thursday"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMuteTimingExists("grafanacloud_mute_timing.test"),
+					resource.TestCheckResourceAttr("grafanacloud_mute_timing.test", "interval.0.weekdays.0", "monday:thursday"),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_mute_timing.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckMuteTimingExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		muteTiming, err := gc.GetMuteTiming(ctx, rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+
+		if muteTiming == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		return nil
+	}
+}
+
+func testAccMuteTimingConfig(resourceName, weekdays string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_mute_timing" "test" {
+  stack = grafanacloud_stack.test.slug
+  name  = "%s"
+
+  interval {
+    times {
+      start = "18:00"
+      end   = "24:00"
+    }
+
+    weekdays = ["%s"]
+    location = "Europe/London"
+  }
+
+  interval {
+    days_of_month = ["25"]
+    months        = ["december"]
+  }
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, weekdays, resourceName, resourceName)
+}
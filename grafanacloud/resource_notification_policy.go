@@ -0,0 +1,345 @@
+package grafanacloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+// Schemas can't be recursive, so the policy tree is limited to this many levels below the root
+const notificationPolicyMaxDepth = 3
+
+var (
+	notificationPolicyMatchTypes = []string{"=", "!=", "=~", "!~"}
+)
+
+func resourceNotificationPolicy() *schema.Resource {
+	s := notificationPolicyTimingSchema()
+	s["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the notification policy in Terraform, which is the slug of the stack.",
+	}
+	s["stack"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Grafana Cloud stack to manage the notification policy of.",
+	}
+	s["organisation"] = resourceOrganisationSchema()
+	s["contact_point"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Name of the contact point alerts not matched by any policy are sent to.",
+	}
+	s["group_by"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Labels to group alerts into a single notification by. Use `...` to group by all labels.",
+	}
+	s["policy"] = notificationPolicySchema(1)
+
+	return &schema.Resource{
+		Description:   "Manages the notification policy tree of Grafana Alerting on a Grafana instance inside a Grafana Cloud stack, routing alerts to contact points by their labels. There's only one notification policy tree per stack, so only one of these resources should exist per stack. Deleting the resource resets the tree to the default, sending all alerts to the default contact point.",
+		CreateContext: resourceNotificationPolicyCreate,
+		ReadContext:   resourceNotificationPolicyRead,
+		UpdateContext: resourceNotificationPolicyUpdate,
+		DeleteContext: resourceNotificationPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(1),
+		},
+		Schema: s,
+	}
+}
+
+// Timing options of policies, which are inherited from the parent policy if not set
+func notificationPolicyTimingSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"group_wait": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "Time to wait before sending the first notification of a new group, e.g. `30s`.",
+			ValidateFunc:     validateDuration,
+			DiffSuppressFunc: suppressEquivalentDurations,
+		},
+		"group_interval": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "Time to wait before notifying about new alerts of a group that's already been notified about, e.g. `5m`.",
+			ValidateFunc:     validateDuration,
+			DiffSuppressFunc: suppressEquivalentDurations,
+		},
+		"repeat_interval": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "Time to wait before notifying again about a group that hasn't changed, e.g. `4h`.",
+			ValidateFunc:     validateDuration,
+			DiffSuppressFunc: suppressEquivalentDurations,
+		},
+	}
+}
+
+func notificationPolicySchema(depth int) *schema.Schema {
+	s := notificationPolicyTimingSchema()
+	s["contact_point"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Name of the contact point matching alerts are sent to. Inherited from the parent policy if not set.",
+	}
+	s["matcher"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Label matchers alerts must all match for the policy to apply.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"label": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Name of the label to match.",
+				},
+				"match": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  fmt.Sprintf("Operator to match the label value with. Might be one of %s.", notificationPolicyMatchTypes),
+					ValidateFunc: validation.StringInSlice(notificationPolicyMatchTypes, false),
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Value or regular expression to match the label value against.",
+				},
+			},
+		},
+	}
+	s["group_by"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Labels to group alerts into a single notification by. Inherited from the parent policy if not set.",
+	}
+	s["continue"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether or not to keep matching the following sibling policies after this one matched.",
+	}
+	s["mute_timings"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Names of the mute timings during which notifications of the policy are muted.",
+	}
+
+	if depth < notificationPolicyMaxDepth {
+		s["policy"] = notificationPolicySchema(depth + 1)
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: fmt.Sprintf("Child policies, matched in order. Policies can be nested up to %d levels deep.", notificationPolicyMaxDepth),
+		Elem:        &schema.Resource{Schema: s},
+	}
+}
+
+func resourceNotificationPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	stack := d.Get("stack").(string)
+	d.SetId(stack)
+
+	return resourceNotificationPolicyUpdate(ctx, d, m)
+}
+
+func resourceNotificationPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack := d.Id()
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	policy, err := client.GetNotificationPolicy(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("contact_point", policy.Receiver); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("group_by", policy.GroupBy); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("group_wait", policy.GroupWait); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("group_interval", policy.GroupInterval); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("repeat_interval", policy.RepeatInterval); err != nil {
+		return diag.FromErr(err)
+	}
+
+	policies, err := flattenNotificationPolicies(policy.Routes, 1)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("policy", policies); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceNotificationPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Id()
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.NotificationPolicy{
+		Receiver:       d.Get("contact_point").(string),
+		GroupBy:        expandStringList(d.Get("group_by").([]interface{})),
+		GroupWait:      d.Get("group_wait").(string),
+		GroupInterval:  d.Get("group_interval").(string),
+		RepeatInterval: d.Get("repeat_interval").(string),
+		Routes:         expandNotificationPolicies(d.Get("policy").([]interface{})),
+	}
+
+	err = client.SetNotificationPolicy(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceNotificationPolicyRead(ctx, d, m)
+}
+
+func resourceNotificationPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack := d.Id()
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.ResetNotificationPolicy(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func expandNotificationPolicies(policies []interface{}) []*grafana.NotificationPolicy {
+	result := make([]*grafana.NotificationPolicy, 0, len(policies))
+
+	for _, p := range policies {
+		policy := p.(map[string]interface{})
+
+		matchers := make([]grafana.ObjectMatcher, 0)
+		for _, m := range policy["matcher"].([]interface{}) {
+			matcher := m.(map[string]interface{})
+			matchers = append(matchers, grafana.ObjectMatcher{
+				matcher["label"].(string),
+				matcher["match"].(string),
+				matcher["value"].(string),
+			})
+		}
+
+		// The deepest policies have no child policies
+		var routes []*grafana.NotificationPolicy
+		if children, ok := policy["policy"]; ok {
+			routes = expandNotificationPolicies(children.([]interface{}))
+		}
+
+		result = append(result, &grafana.NotificationPolicy{
+			Receiver:          policy["contact_point"].(string),
+			GroupBy:           expandStringList(policy["group_by"].([]interface{})),
+			Continue:          policy["continue"].(bool),
+			ObjectMatchers:    matchers,
+			MuteTimeIntervals: expandStringList(policy["mute_timings"].([]interface{})),
+			GroupWait:         policy["group_wait"].(string),
+			GroupInterval:     policy["group_interval"].(string),
+			RepeatInterval:    policy["repeat_interval"].(string),
+			Routes:            routes,
+		})
+	}
+
+	return result
+}
+
+// Fails if the tree in Grafana is nested deeper than the schema supports, as the deeper policies
+// would otherwise silently be removed on the next update.
+func flattenNotificationPolicies(policies []*grafana.NotificationPolicy, depth int) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(policies))
+
+	for _, policy := range policies {
+		matchers := make([]map[string]interface{}, 0, len(policy.ObjectMatchers))
+		for _, matcher := range policy.ObjectMatchers {
+			matchers = append(matchers, map[string]interface{}{
+				"label": matcher[0],
+				"match": matcher[1],
+				"value": matcher[2],
+			})
+		}
+
+		flattened := map[string]interface{}{
+			"contact_point":   policy.Receiver,
+			"matcher":         matchers,
+			"group_by":        policy.GroupBy,
+			"continue":        policy.Continue,
+			"mute_timings":    policy.MuteTimeIntervals,
+			"group_wait":      policy.GroupWait,
+			"group_interval":  policy.GroupInterval,
+			"repeat_interval": policy.RepeatInterval,
+		}
+
+		if depth < notificationPolicyMaxDepth {
+			children, err := flattenNotificationPolicies(policy.Routes, depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			flattened["policy"] = children
+		} else if len(policy.Routes) > 0 {
+			return nil, fmt.Errorf("notification policies in Grafana are nested deeper than the supported %d levels", notificationPolicyMaxDepth)
+		}
+
+		result = append(result, flattened)
+	}
+
+	return result, nil
+}
+
+func expandStringList(list []interface{}) []string {
+	result := make([]string, 0, len(list))
+	for _, v := range list {
+		result = append(result, v.(string))
+	}
+
+	return result
+}
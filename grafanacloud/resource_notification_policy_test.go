@@ -0,0 +1,222 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+func TestAccNotificationPolicy_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationPolicyConfig(resourceName, "4h"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotificationPolicy("grafanacloud_notification_policy.test", resourceName+"-default"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "id", resourceName+"slug"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "contact_point", resourceName+"-default"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "group_by.#", "2"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "group_wait", "30s"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "repeat_interval", "4h"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.#", "2"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.0.contact_point", resourceName+"-oncall"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.0.matcher.0.label", "severity"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.0.matcher.0.match", "="),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.0.matcher.0.value", "critical"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.0.continue", "true"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.0.mute_timings.0", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.0.policy.#", "1"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.0.policy.0.matcher.0.match", "=~"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "policy.1.contact_point", ""),
+				),
+			},
+			{
+				Config: testAccNotificationPolicyConfig(resourceName, "12h"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotificationPolicy("grafanacloud_notification_policy.test", resourceName+"-default"),
+					resource.TestCheckResourceAttr("grafanacloud_notification_policy.test", "repeat_interval", "12h"),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_notification_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNotificationPolicy_TooDeep(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfig(resourceName),
+				Check:  testAccSetNotificationPolicyDepth(resourceName+"-slug", 4),
+			},
+			{
+				Config:        testAccNotificationPolicyConfigDefault(resourceName),
+				ResourceName:  "grafanacloud_notification_policy.test",
+				ImportState:   true,
+				ImportStateId: resourceName + "-slug",
+				ExpectError:   regexp.MustCompile("nested deeper than the supported 3 levels"),
+			},
+		},
+	})
+}
+
+// Sets a policy tree of the given depth below the root, like one created in the Grafana UI.
+func testAccSetNotificationPolicyDepth(stack string, depth int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, stack)
+		if err != nil {
+			return err
+		}
+
+		policy := &grafana.NotificationPolicy{Receiver: "grafana-default-email"}
+		for parent, i := policy, 0; i < depth; i++ {
+			child := &grafana.NotificationPolicy{
+				ObjectMatchers: []grafana.ObjectMatcher{{"level", "=", fmt.Sprint(i)}},
+			}
+
+			parent.Routes = []*grafana.NotificationPolicy{child}
+			parent = child
+		}
+
+		return gc.SetNotificationPolicy(ctx, policy)
+	}
+}
+
+func testAccCheckNotificationPolicy(resourceName, contactPoint string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		policy, err := gc.GetNotificationPolicy(ctx)
+		if err != nil {
+			return err
+		}
+
+		if policy.Receiver != contactPoint {
+			return fmt.Errorf("resource `%s` routes to `%s` in Grafana, expected `%s`", resourceName, policy.Receiver, contactPoint)
+		}
+
+		return nil
+	}
+}
+
+func testAccNotificationPolicyConfig(resourceName, repeatInterval string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_notification_policy" "test" {
+  stack           = grafanacloud_stack.test.slug
+  contact_point   = grafanacloud_contact_point.default.name
+  group_by        = ["grafana_folder", "alertname"]
+  group_wait      = "30s"
+  repeat_interval = "%s"
+
+  policy {
+    contact_point = grafanacloud_contact_point.oncall.name
+    continue      = true
+    mute_timings  = [grafanacloud_mute_timing.test.name]
+
+    matcher {
+      label = "severity"
+      match = "="
+      value = "critical"
+    }
+
+    policy {
+      group_by = ["..."]
+
+      matcher {
+        label = "team"
+        match = "=~"
+        value = "team-(a|b)"
+      }
+    }
+  }
+
+  policy {
+    group_interval = "10m"
+
+    matcher {
+      label = "severity"
+      match = "!="
+      value = "critical"
+    }
+  }
+}
+
+resource "grafanacloud_contact_point" "default" {
+  stack = grafanacloud_stack.test.slug
+  name  = "%s-default"
+
+  email {
+    addresses = ["team@example.com"]
+  }
+}
+
+resource "grafanacloud_contact_point" "oncall" {
+  stack = grafanacloud_stack.test.slug
+  name  = "%s-oncall"
+
+  pagerduty {
+    integration_key = "secret-key"
+  }
+}
+
+resource "grafanacloud_mute_timing" "test" {
+  stack = grafanacloud_stack.test.slug
+  name  = "%s"
+
+  interval {
+    weekdays = ["saturday", "sunday"]
+  }
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, repeatInterval, resourceName, resourceName, resourceName, resourceName, resourceName)
+}
+
+func testAccNotificationPolicyConfigDefault(resourceName string) string {
+	return testAccStackConfig(resourceName) + `
+resource "grafanacloud_notification_policy" "test" {
+  stack         = grafanacloud_stack.test.slug
+  contact_point = "grafana-default-email"
+}
+`
+}
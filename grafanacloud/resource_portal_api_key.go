@@ -20,6 +20,9 @@ func resourcePortalApiKey() *schema.Resource {
 		CreateContext: resourcePortalApiKeyCreate,
 		ReadContext:   resourcePortalApiKeyRead,
 		DeleteContext: resourcePortalApiKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(1),
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
@@ -45,6 +48,7 @@ func resourcePortalApiKey() *schema.Resource {
 				Sensitive:   true,
 				Description: "The generated API key.",
 			},
+			"organisation": resourceOrganisationSchema(),
 		},
 	}
 }
@@ -59,7 +63,7 @@ func resourcePortalApiKeyCreate(ctx context.Context, d *schema.ResourceData, m i
 	req := &portal.CreateAPIKeyInput{
 		Name:         d.Get("name").(string),
 		Role:         d.Get("role").(string),
-		Organisation: p.Organisation,
+		Organisation: p.organisation(d),
 	}
 
 	resp, err := p.Client.CreateAPIKey(ctx, req)
@@ -77,7 +81,11 @@ func resourcePortalApiKeyRead(ctx context.Context, d *schema.ResourceData, m int
 	var diags diag.Diagnostics
 	p := m.(*Provider)
 
-	resp, err := p.Client.ListAPIKeys(ctx, p.Organisation)
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := p.Client.ListAPIKeys(ctx, p.organisation(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -103,7 +111,7 @@ func resourcePortalApiKeyDelete(ctx context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 	p := m.(*Provider)
 
-	err := p.Client.DeleteAPIKey(ctx, p.Organisation, d.Id())
+	err := p.Client.DeleteAPIKey(ctx, p.organisation(d), d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -70,6 +70,12 @@ func TestAccPortalApiKey_Basic(t *testing.T) {
 							resource.TestCheckResourceAttr("grafanacloud_portal_api_key.test", "role", tt.role),
 						),
 					},
+					{
+						ResourceName:            "grafanacloud_portal_api_key.test",
+						ImportState:             true,
+						ImportStateVerify:       true,
+						ImportStateVerifyIgnore: []string{"key"},
+					},
 				},
 			})
 		})
@@ -0,0 +1,70 @@
+package grafanacloud
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+func resourcePrometheusRuleNamespace() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single namespace of recording and alerting rules in the hosted Prometheus instance of a Grafana Cloud stack. The rules are evaluated by the ruler of the instance, independently of Grafana Alerting. PromQL expressions are only checked for balanced brackets and quotes when planning, and fully parsed by the ruler when applying.",
+		CreateContext: ruleNamespaceCreate((*portal.Client).GetPrometheusRulerClient),
+		ReadContext:   ruleNamespaceRead((*portal.Client).GetPrometheusRulerClient),
+		UpdateContext: ruleNamespaceUpdate((*portal.Client).GetPrometheusRulerClient),
+		DeleteContext: ruleNamespaceDelete((*portal.Client).GetPrometheusRulerClient),
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(2),
+		},
+		Schema: ruleNamespaceSchema("Prometheus", validatePrometheusRuleExpr),
+	}
+}
+
+// The provider doesn't depend on the PromQL parser of Prometheus, whose module would add much of Prometheus'
+// dependencies to the provider. Expressions are only checked for unbalanced brackets and quotes, which
+// would fail to parse anyway, and otherwise validated by the ruler.
+func validatePrometheusRuleExpr(expr string) error {
+	opening := map[rune]rune{')': '(', ']': '[', '}': '{'}
+
+	var open []rune
+	var quote rune
+	escaped, comment := false, false
+	for _, c := range expr {
+		switch {
+		case comment:
+			comment = c != '\n'
+		case quote != 0:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\' && quote != '`':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+		case c == '#':
+			comment = true
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			open = append(open, c)
+		case opening[c] != 0:
+			if len(open) == 0 || open[len(open)-1] != opening[c] {
+				return fmt.Errorf("`%c` doesn't close a `%c`", c, opening[c])
+			}
+
+			open = open[:len(open)-1]
+		}
+	}
+
+	if quote != 0 {
+		return fmt.Errorf("string quoted by `%c` isn't terminated", quote)
+	}
+
+	if len(open) > 0 {
+		return fmt.Errorf("`%c` isn't closed", open[len(open)-1])
+	}
+
+	return nil
+}
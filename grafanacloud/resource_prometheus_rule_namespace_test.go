@@ -0,0 +1,263 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/ruler"
+)
+
+func TestAccPrometheusRuleNamespace_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusRuleNamespaceConfig(resourceName, `
+    groups:
+      - name: recording
+        interval: 1m
+        rules:
+          - record: job:http_requests:rate5m
+            expr: sum by (job) (rate(http_requests_total[5m]))
+      - name: alerting
+        rules:
+          - alert: HighErrorRate
+            expr: job:http_errors:rate5m / job:http_requests:rate5m > 0.05
+            for: 10m
+            labels:
+              severity: critical
+            annotations:
+              summary: High error rate of {{ $labels.job }}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPrometheusRuleNamespaceExists("grafanacloud_prometheus_rule_namespace.test", 2),
+					resource.TestCheckResourceAttr("grafanacloud_prometheus_rule_namespace.test", "id", resourceName+"slug/"+resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_prometheus_rule_namespace.test", "name", resourceName),
+					resource.TestMatchResourceAttr("grafanacloud_prometheus_rule_namespace.test", "content", regexp.MustCompile(`(?s)^groups:\n  - name: alerting\n.*  - name: recording\n`)),
+				),
+			},
+			{
+				Config: testAccPrometheusRuleNamespaceConfig(resourceName, `
+    groups:
+      - name: recording
+        interval: 2m
+        rules:
+          - record: job:http_requests:rate5m
+            expr: sum by (job) (rate(http_requests_total[5m]))
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPrometheusRuleNamespaceExists("grafanacloud_prometheus_rule_namespace.test", 1),
+					resource.TestMatchResourceAttr("grafanacloud_prometheus_rule_namespace.test", "content", regexp.MustCompile(`interval: 2m`)),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_prometheus_rule_namespace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPrometheusRuleNamespace_Extensions(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrometheusRuleNamespaceConfig(resourceName, `
+    groups:
+      - name: alerting
+        limit: 10
+        query_offset: 1m
+        source_tenants: [team-a, team-b]
+        rules:
+          - alert: InstanceDown
+            expr: up{job="api", team!~"^\\W*[)]"} == 0
+            for: 5m
+            keep_firing_for: 10m
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPrometheusRuleNamespaceExists("grafanacloud_prometheus_rule_namespace.test", 1),
+					testAccCheckPrometheusRuleGroup("grafanacloud_prometheus_rule_namespace.test", "alerting", func(group *ruler.RuleGroup) error {
+						if group.Limit != 10 || group.QueryOffset != "1m" || len(group.SourceTenants) != 2 || group.Rules[0].KeepFiringFor != "10m" {
+							return fmt.Errorf("rule group `alerting` lost fields in the ruler: %+v", group)
+						}
+
+						return nil
+					}),
+					resource.TestMatchResourceAttr("grafanacloud_prometheus_rule_namespace.test", "content", regexp.MustCompile(`(?s)limit: 10\n.*keep_firing_for: 10m\n`)),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_prometheus_rule_namespace.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPrometheusRuleNamespace_Invalid(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	invalid := map[string]string{
+		"unknown field": `
+    groups:
+      - name: test
+        rules:
+          - record: test
+            expression: up
+`,
+		"no groups": `
+    groups: []
+`,
+		"duplicate group": `
+    groups:
+      - name: test
+        rules:
+          - record: test
+            expr: up
+      - name: test
+        rules:
+          - record: test
+            expr: up
+`,
+		"record and alert": `
+    groups:
+      - name: test
+        rules:
+          - record: test
+            alert: Test
+            expr: up
+`,
+		"unbalanced expr": `
+    groups:
+      - name: test
+        rules:
+          - record: test
+            expr: sum(rate(up[5m])
+`,
+		"recording rule keeping firing": `
+    groups:
+      - name: test
+        rules:
+          - record: test
+            expr: up
+            keep_firing_for: 5m
+`,
+		"invalid duration": `
+    groups:
+      - name: test
+        rules:
+          - alert: Test
+            expr: up == 0
+            for: 5 minutes
+`,
+	}
+
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config:      testAccPrometheusRuleNamespaceConfig(resourceName, content),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(`content`),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccCheckPrometheusRuleNamespaceExists(resourceName string, groups int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		client, err := p.Client.GetPrometheusRulerClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.ListRuleGroups(ctx, rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+
+		if len(resp) != groups {
+			return fmt.Errorf("resource `%s` has %d rule groups in the ruler, expected %d", resourceName, len(resp), groups)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckPrometheusRuleGroup(resourceName, name string, check func(*ruler.RuleGroup) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		client, err := p.Client.GetPrometheusRulerClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		groups, err := client.ListRuleGroups(ctx, rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+
+		for _, group := range groups {
+			if group.Name == name {
+				return check(group)
+			}
+		}
+
+		return fmt.Errorf("resource `%s` has no rule group `%s` in the ruler", resourceName, name)
+	}
+}
+
+func testAccPrometheusRuleNamespaceConfig(resourceName, content string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_prometheus_rule_namespace" "test" {
+  stack   = grafanacloud_stack.test.slug
+  name    = "%s"
+  content = <<-EOT
+%s
+  EOT
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, content, resourceName, resourceName)
+}
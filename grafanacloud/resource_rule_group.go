@@ -0,0 +1,549 @@
+package grafanacloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+var (
+	alertRuleNoDataStates = []string{
+		grafana.AlertRuleNoDataStateNoData,
+		grafana.AlertRuleNoDataStateAlerting,
+		grafana.AlertRuleNoDataStateOK,
+	}
+
+	alertRuleExecErrStates = []string{
+		grafana.AlertRuleExecErrStateAlerting,
+		grafana.AlertRuleExecErrStateError,
+		grafana.AlertRuleExecErrStateOK,
+	}
+
+	// Query options Grafana adds to the model of every query if they're not set
+	alertQueryModelDefaults = map[string]interface{}{
+		"intervalMs":    float64(1000),
+		"maxDataPoints": float64(43200),
+	}
+)
+
+func resourceRuleGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single Grafana Alerting rule group on a Grafana instance inside a Grafana Cloud stack. All rules of the group are evaluated together at the same interval.",
+		CreateContext: resourceRuleGroupCreate,
+		ReadContext:   resourceRuleGroupRead,
+		UpdateContext: resourceRuleGroupUpdate,
+		DeleteContext: resourceRuleGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(3),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the rule group in Terraform, composed as `stack/folder_uid/name`.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack to create the rule group in.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"folder_uid": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "UID of the folder the rule group is stored in.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the rule group, unique within the folder.",
+			},
+			"interval_seconds": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "Interval in seconds at which the rules of the group are evaluated. Must be a multiple of 10.",
+				ValidateFunc: validation.All(validation.IntAtLeast(10), validation.IntDivisibleBy(10)),
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Alert rules of the group.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uid": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "UID of the rule. Generated by Grafana if not set. Rules without UID are matched to the existing rules by name.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the rule.",
+						},
+						"condition": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "`ref_id` of the query or expression which decides whether or not the rule fires.",
+						},
+						"for": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "0s",
+							Description:      "Duration for which the condition must be true before the rule fires, e.g. `5m`.",
+							ValidateFunc:     validateDuration,
+							DiffSuppressFunc: suppressEquivalentDurations,
+						},
+						"no_data_state": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      grafana.AlertRuleNoDataStateNoData,
+							Description:  fmt.Sprintf("State of the rule if its queries return no data. Might be one of %s.", alertRuleNoDataStates),
+							ValidateFunc: validation.StringInSlice(alertRuleNoDataStates, false),
+						},
+						"exec_err_state": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      grafana.AlertRuleExecErrStateAlerting,
+							Description:  fmt.Sprintf("State of the rule if its queries fail or time out. Might be one of %s.", alertRuleExecErrStates),
+							ValidateFunc: validation.StringInSlice(alertRuleExecErrStates, false),
+						},
+						"labels": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Labels attached to alerts of the rule, used to route them to notification policies.",
+						},
+						"annotations": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Annotations attached to alerts of the rule, e.g. `summary` or `runbook_url`.",
+						},
+						"is_paused": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether or not the evaluation of the rule is paused.",
+						},
+						"data": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Description: "Queries and expressions the rule evaluates.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ref_id": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Reference of the query, unique within the rule, e.g. `A`.",
+									},
+									"datasource_uid": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "UID of the data source to query. Expressions use `__expr__`.",
+									},
+									"query_type": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Type of the query, depending on the data source.",
+									},
+									"relative_time_range": {
+										Type:        schema.TypeList,
+										Required:    true,
+										MaxItems:    1,
+										Description: "Time range to query, relative to the time of the evaluation.",
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"from": {
+													Type:         schema.TypeInt,
+													Required:     true,
+													Description:  "Start of the range in seconds before the evaluation, e.g. `600` for the last 10 minutes.",
+													ValidateFunc: validation.IntAtLeast(0),
+												},
+												"to": {
+													Type:         schema.TypeInt,
+													Required:     true,
+													Description:  "End of the range in seconds before the evaluation, usually `0`.",
+													ValidateFunc: validation.IntAtLeast(0),
+												},
+											},
+										},
+									},
+									"model": {
+										Type:         schema.TypeString,
+										Required:     true,
+										StateFunc:    normalizeAlertQueryModel,
+										ValidateFunc: validation.StringIsJSON,
+										Description:  "The query or expression as JSON object, depending on the data source, e.g. `{\"expr\": \"up == 0\"}`.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a duration like `5m` or `1h30m`: %v", k, err)}
+	}
+
+	return nil, nil
+}
+
+// Grafana returns durations in their shortest form, e.g. `1h` for `60m`
+func suppressEquivalentDurations(k, old, new string, d *schema.ResourceData) bool {
+	oldDuration, err := time.ParseDuration(old)
+	if err != nil {
+		return false
+	}
+
+	newDuration, err := time.ParseDuration(new)
+	if err != nil {
+		return false
+	}
+
+	return oldDuration == newDuration
+}
+
+func resourceRuleGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	folderUID := d.Get("folder_uid").(string)
+	name := d.Get("name").(string)
+
+	existing, err := client.GetRuleGroup(ctx, folderUID, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Saving the group would silently replace the existing one
+	if existing != nil {
+		return diag.Errorf("rule group `%s` already exists in folder `%s`, import it instead", name, folderUID)
+	}
+
+	req, err := expandRuleGroup(d, folderUID, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.PutRuleGroup(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(compositeID(stack, compositeID(folderUID, name)))
+
+	return resourceRuleGroupRead(ctx, d, m)
+}
+
+func resourceRuleGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack, folderUID, name, err := splitRuleGroupID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	group, err := client.GetRuleGroup(ctx, folderUID, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if group == nil {
+		d.SetId("")
+		return diags
+	}
+
+	rules, err := flattenAlertRules(group.Rules)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("folder_uid", group.FolderUID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", group.Title); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("interval_seconds", group.Interval); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("rule", rules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceRuleGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack, folderUID, name, err := splitRuleGroupID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req, err := expandRuleGroup(d, folderUID, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.PutRuleGroup(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceRuleGroupRead(ctx, d, m)
+}
+
+func resourceRuleGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, folderUID, name, err := splitRuleGroupID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteRuleGroup(ctx, folderUID, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func splitRuleGroupID(id string) (string, string, string, error) {
+	format := "stack/folder_uid/name"
+	stack, group, err := splitCompositeID(id, format)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// Folder UIDs can't contain slashes, but group names can
+	folderUID, name, err := splitCompositeID(group, format)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid ID `%s`, expected `%s`", id, format)
+	}
+
+	return stack, folderUID, name, nil
+}
+
+func expandRuleGroup(d *schema.ResourceData, folderUID, name string) (*grafana.RuleGroup, error) {
+	configured := d.Get("rule").([]interface{})
+	uids := ruleUIDs(d, configured)
+
+	rules := make([]*grafana.AlertRule, 0)
+	for i, r := range configured {
+		rule := r.(map[string]interface{})
+
+		data, err := expandAlertQueries(rule["data"].([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, &grafana.AlertRule{
+			UID:          uids[i],
+			FolderUID:    folderUID,
+			RuleGroup:    name,
+			Title:        rule["name"].(string),
+			Condition:    rule["condition"].(string),
+			Data:         data,
+			NoDataState:  rule["no_data_state"].(string),
+			ExecErrState: rule["exec_err_state"].(string),
+			For:          rule["for"].(string),
+			Labels:       expandStringMap(rule["labels"].(map[string]interface{})),
+			Annotations:  expandStringMap(rule["annotations"].(map[string]interface{})),
+			IsPaused:     rule["is_paused"].(bool),
+		})
+	}
+
+	return &grafana.RuleGroup{
+		Title:     name,
+		FolderUID: folderUID,
+		Interval:  d.Get("interval_seconds").(int),
+		Rules:     rules,
+	}, nil
+}
+
+// Rules are a list, so Terraform keeps the computed UID of a rule at its position. Removing a rule
+// would move its UID onto the following rule, so rules are matched to the existing ones by name,
+// which is unique within a folder. UIDs unknown to the group were set explicitly and are kept.
+func ruleUIDs(d *schema.ResourceData, configured []interface{}) []string {
+	old, _ := d.GetChange("rule")
+
+	existingByName := make(map[string]string)
+	existingByUID := make(map[string]string)
+	for _, r := range old.([]interface{}) {
+		rule := r.(map[string]interface{})
+		existingByName[rule["name"].(string)] = rule["uid"].(string)
+		existingByUID[rule["uid"].(string)] = rule["name"].(string)
+	}
+
+	names := make(map[string]bool)
+	for _, r := range configured {
+		names[r.(map[string]interface{})["name"].(string)] = true
+	}
+
+	result := make([]string, 0, len(configured))
+	for _, r := range configured {
+		rule := r.(map[string]interface{})
+		uid := rule["uid"].(string)
+
+		if existingName, ok := existingByUID[uid]; uid == "" || ok {
+			if existingUID, ok := existingByName[rule["name"].(string)]; ok {
+				uid = existingUID
+			} else if names[existingName] {
+				// The UID belongs to another rule which is still configured
+				uid = ""
+			}
+		}
+
+		result = append(result, uid)
+	}
+
+	return result
+}
+
+func expandAlertQueries(queries []interface{}) ([]*grafana.AlertQuery, error) {
+	result := make([]*grafana.AlertQuery, 0, len(queries))
+
+	for _, q := range queries {
+		query := q.(map[string]interface{})
+
+		model := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(query["model"].(string)), &model); err != nil {
+			return nil, fmt.Errorf("invalid model of query `%s`: %v", query["ref_id"], err)
+		}
+
+		timeRange := &grafana.RelativeTimeRange{}
+		for _, t := range query["relative_time_range"].([]interface{}) {
+			timeRange.From = t.(map[string]interface{})["from"].(int)
+			timeRange.To = t.(map[string]interface{})["to"].(int)
+		}
+
+		result = append(result, &grafana.AlertQuery{
+			RefID:             query["ref_id"].(string),
+			QueryType:         query["query_type"].(string),
+			RelativeTimeRange: timeRange,
+			DatasourceUID:     query["datasource_uid"].(string),
+			Model:             model,
+		})
+	}
+
+	return result, nil
+}
+
+func flattenAlertRules(rules []*grafana.AlertRule) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(rules))
+
+	for _, rule := range rules {
+		data := make([]map[string]interface{}, 0, len(rule.Data))
+		for _, query := range rule.Data {
+			model, err := json.Marshal(query.Model)
+			if err != nil {
+				return nil, err
+			}
+
+			timeRange := make([]map[string]interface{}, 0, 1)
+			if query.RelativeTimeRange != nil {
+				timeRange = append(timeRange, map[string]interface{}{
+					"from": query.RelativeTimeRange.From,
+					"to":   query.RelativeTimeRange.To,
+				})
+			}
+
+			data = append(data, map[string]interface{}{
+				"ref_id":              query.RefID,
+				"datasource_uid":      query.DatasourceUID,
+				"query_type":          query.QueryType,
+				"relative_time_range": timeRange,
+				"model":               normalizeAlertQueryModel(string(model)),
+			})
+		}
+
+		result = append(result, map[string]interface{}{
+			"uid":            rule.UID,
+			"name":           rule.Title,
+			"condition":      rule.Condition,
+			"for":            rule.For,
+			"no_data_state":  rule.NoDataState,
+			"exec_err_state": rule.ExecErrState,
+			"labels":         rule.Labels,
+			"annotations":    rule.Annotations,
+			"is_paused":      rule.IsPaused,
+			"data":           data,
+		})
+	}
+
+	return result, nil
+}
+
+// Normalises the model of a query, so the options Grafana adds (and the `refId` it duplicates into
+// the model) don't result in a diff.
+func normalizeAlertQueryModel(model interface{}) string {
+	return normalizeJSON(model, func(model string) (interface{}, error) {
+		data := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(model), &data); err != nil {
+			return nil, err
+		}
+
+		delete(data, "refId")
+		for k, v := range alertQueryModelDefaults {
+			if data[k] == v {
+				delete(data, k)
+			}
+		}
+
+		return data, nil
+	})
+}
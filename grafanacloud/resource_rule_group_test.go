@@ -0,0 +1,333 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccRuleGroup_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRuleGroupConfig(resourceName, 60, "5m", "critical"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRuleGroupExists("grafanacloud_rule_group.test", 1),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "id", resourceName+"slug/"+resourceName+"/"+resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "folder_uid", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "interval_seconds", "60"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.#", "1"),
+					resource.TestCheckResourceAttrSet("grafanacloud_rule_group.test", "rule.0.uid"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.name", "Instance down"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.condition", "B"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.for", "5m"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.no_data_state", "NoData"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.exec_err_state", "Alerting"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.labels.severity", "critical"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.annotations.summary", "Instance is down"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.is_paused", "false"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.data.#", "2"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.data.0.ref_id", "A"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.data.0.datasource_uid", "grafanacloud-prom"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.data.0.relative_time_range.0.from", "600"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.data.0.relative_time_range.0.to", "0"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.data.0.model", `{"expr":"up == 0"}`),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.data.1.datasource_uid", "__expr__"),
+				),
+			},
+			{
+				Config: testAccRuleGroupConfig(resourceName, 120, "10m", "warning"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRuleGroupExists("grafanacloud_rule_group.test", 1),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "interval_seconds", "120"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.for", "10m"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.labels.severity", "warning"),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_rule_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRuleGroup_MultipleRules(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRuleGroupConfigMultipleRules(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRuleGroupExists("grafanacloud_rule_group.test", 2),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.#", "2"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.uid", resourceName+"-up"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.0.for", "0s"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.1.name", "Too many errors"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.1.no_data_state", "OK"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.1.exec_err_state", "Error"),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.1.is_paused", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRuleGroup_RemoveRule(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	var uid string
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRuleGroupConfigRules(resourceName, "First", "Second", "Third"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRuleGroupExists("grafanacloud_rule_group.test", 3),
+					testAccStoreAttr("grafanacloud_rule_group.test", "rule.2.uid", &uid),
+				),
+			},
+			{
+				// The remaining rules keep their UIDs instead of taking them over by position
+				Config: testAccRuleGroupConfigRules(resourceName, "First", "Third"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRuleGroupExists("grafanacloud_rule_group.test", 2),
+					resource.TestCheckResourceAttr("grafanacloud_rule_group.test", "rule.1.name", "Third"),
+					resource.TestCheckResourceAttrPtr("grafanacloud_rule_group.test", "rule.1.uid", &uid),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRuleGroupExists(resourceName string, rules int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		group, err := gc.GetRuleGroup(ctx, rs.Primary.Attributes["folder_uid"], rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+
+		if group == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		if len(group.Rules) != rules {
+			return fmt.Errorf("resource `%s` has %d rules in Grafana, expected %d", resourceName, len(group.Rules), rules)
+		}
+
+		return nil
+	}
+}
+
+func testAccRuleGroupConfig(resourceName string, interval int, forDuration, severity string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_rule_group" "test" {
+  stack            = grafanacloud_stack.test.slug
+  folder_uid       = grafanacloud_folder.test.uid
+  name             = "%s"
+  interval_seconds = %d
+
+  rule {
+    name      = "Instance down"
+    condition = "B"
+    for       = "%s"
+
+    labels = {
+      severity = "%s"
+    }
+
+    annotations = {
+      summary = "Instance is down"
+    }
+
+    data {
+      ref_id         = "A"
+      datasource_uid = "grafanacloud-prom"
+
+      relative_time_range {
+        from = 600
+        to   = 0
+      }
+
+      model = jsonencode({
+        expr = "up == 0"
+      })
+    }
+
+    data {
+      ref_id         = "B"
+      datasource_uid = "__expr__"
+
+      relative_time_range {
+        from = 0
+        to   = 0
+      }
+
+      model = jsonencode({
+        type       = "threshold"
+        expression = "A"
+        conditions = [{
+          evaluator = {
+            type   = "gt"
+            params = [0]
+          }
+        }]
+      })
+    }
+  }
+}
+
+resource "grafanacloud_folder" "test" {
+  stack = grafanacloud_stack.test.slug
+  uid   = "%s"
+  title = "%s"
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, interval, forDuration, severity, resourceName, resourceName, resourceName, resourceName)
+}
+
+func testAccRuleGroupConfigMultipleRules(resourceName string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_rule_group" "test" {
+  stack            = grafanacloud_stack.test.slug
+  folder_uid       = grafanacloud_folder.test.uid
+  name             = "%s"
+  interval_seconds = 60
+
+  rule {
+    uid       = "%s-up"
+    name      = "Instance down"
+    condition = "A"
+
+    data {
+      ref_id         = "A"
+      datasource_uid = "grafanacloud-prom"
+
+      relative_time_range {
+        from = 600
+        to   = 0
+      }
+
+      model = jsonencode({
+        expr = "up == 0"
+      })
+    }
+  }
+
+  rule {
+    name           = "Too many errors"
+    condition      = "A"
+    no_data_state  = "OK"
+    exec_err_state = "Error"
+    is_paused      = true
+
+    data {
+      ref_id         = "A"
+      datasource_uid = "grafanacloud-logs"
+
+      relative_time_range {
+        from = 300
+        to   = 0
+      }
+
+      model = jsonencode({
+        expr = "sum(rate({app=\"api\"} |= \"error\" [5m])) > 10"
+      })
+    }
+  }
+}
+
+resource "grafanacloud_folder" "test" {
+  stack = grafanacloud_stack.test.slug
+  title = "%s"
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, resourceName, resourceName, resourceName, resourceName)
+}
+
+func testAccRuleGroupConfigRules(resourceName string, names ...string) string {
+	rules := ""
+	for _, name := range names {
+		rules += fmt.Sprintf(`
+  rule {
+    name      = "%s"
+    condition = "A"
+
+    data {
+      ref_id         = "A"
+      datasource_uid = "grafanacloud-prom"
+
+      relative_time_range {
+        from = 600
+        to   = 0
+      }
+
+      model = jsonencode({
+        expr = "up == 0"
+      })
+    }
+  }
+`, name)
+	}
+
+	return fmt.Sprintf(`
+resource "grafanacloud_rule_group" "test" {
+  stack            = grafanacloud_stack.test.slug
+  folder_uid       = grafanacloud_folder.test.uid
+  name             = "%s"
+  interval_seconds = 60
+%s}
+
+resource "grafanacloud_folder" "test" {
+  stack = grafanacloud_stack.test.slug
+  title = "%s"
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, rules, resourceName, resourceName, resourceName)
+}
@@ -0,0 +1,376 @@
+package grafanacloud
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/ruler"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	prometheusDurationRegex   = regexp.MustCompile(`^(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?$`)
+	prometheusMetricNameRegex = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	prometheusLabelNameRegex  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Rules are configured in the format of Prometheus rule files, which Loki uses as well
+type ruleNamespaceContent struct {
+	Groups []*ruler.RuleGroup `yaml:"groups"`
+}
+
+// Returns the client for the ruler of the hosted instance of the stack evaluating the rules, i.e. a
+// method expression of portal.Client.
+type rulerClientGetter func(c *portal.Client, ctx context.Context, orgName, stackName string) (*ruler.Client, error)
+
+// Validates the expression of a rule, which depends on the kind of the hosted instance.
+type ruleExprValidator func(expr string) error
+
+func ruleNamespaceSchema(kind string, validateExpr ruleExprValidator) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the rule namespace in Terraform, composed as `stack/name`.",
+		},
+		"stack": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: fmt.Sprintf("Grafana Cloud stack whose hosted %s instance evaluates the rules.", kind),
+		},
+		"organisation": resourceOrganisationSchema(),
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Name of the namespace.",
+		},
+		"content": {
+			Type:         schema.TypeString,
+			Required:     true,
+			StateFunc:    normalizeRuleNamespaceContent,
+			ValidateFunc: validateRuleNamespaceContent(validateExpr),
+			Description:  "Rule groups of the namespace in the YAML format of Prometheus rule files, i.e. a list of `groups`. The structure of the groups and rules is validated when planning, while expressions are only fully validated by the ruler when applying. Formatting and the order of groups don't result in a diff.",
+		},
+	}
+}
+
+func validateRuleNamespaceContent(validateExpr ruleExprValidator) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) ([]string, []error) {
+		content, err := parseRuleNamespaceContent(v.(string), true)
+		if err != nil {
+			return nil, []error{fmt.Errorf("%q is not a valid rule file: %v", k, err)}
+		}
+
+		var errs []error
+		if len(content.Groups) == 0 {
+			errs = append(errs, fmt.Errorf("%q must contain at least one rule group", k))
+		}
+
+		names := make(map[string]bool)
+		for i, group := range content.Groups {
+			if group.Name == "" {
+				errs = append(errs, fmt.Errorf("%q: group %d has no name", k, i+1))
+			}
+
+			if names[group.Name] {
+				errs = append(errs, fmt.Errorf("%q: group name `%s` is not unique", k, group.Name))
+			}
+
+			names[group.Name] = true
+			for _, err := range validateRuleGroup(group) {
+				errs = append(errs, fmt.Errorf("%q: group `%s` %v", k, group.Name, err))
+			}
+
+			if len(group.Rules) == 0 {
+				errs = append(errs, fmt.Errorf("%q: group `%s` has no rules", k, group.Name))
+			}
+
+			for j, rule := range group.Rules {
+				for _, err := range validateRule(rule, validateExpr) {
+					errs = append(errs, fmt.Errorf("%q: rule %d of group `%s` %v", k, j+1, group.Name, err))
+				}
+			}
+		}
+
+		return nil, errs
+	}
+}
+
+func validateRuleGroup(group *ruler.RuleGroup) []error {
+	var errs []error
+
+	durations := []struct {
+		name  string
+		value string
+	}{
+		{"interval", group.Interval},
+		{"query_offset", group.QueryOffset},
+		{"evaluation_delay", group.EvaluationDelay},
+	}
+
+	for _, duration := range durations {
+		if duration.value != "" && !isPrometheusDuration(duration.value) {
+			errs = append(errs, fmt.Errorf("has `%s` set to `%s`, which is not a valid duration", duration.name, duration.value))
+		}
+	}
+
+	if group.Limit < 0 {
+		errs = append(errs, fmt.Errorf("has a negative `limit`"))
+	}
+
+	for name := range group.Labels {
+		if !prometheusLabelNameRegex.MatchString(name) {
+			errs = append(errs, fmt.Errorf("has label `%s`, which is not a valid label name", name))
+		}
+	}
+
+	return errs
+}
+
+func validateRule(rule *ruler.Rule, validateExpr ruleExprValidator) []error {
+	var errs []error
+
+	switch {
+	case rule.Record != "" && rule.Alert != "":
+		errs = append(errs, fmt.Errorf("must set only one of `record` and `alert`"))
+	case rule.Record == "" && rule.Alert == "":
+		errs = append(errs, fmt.Errorf("must set either `record` or `alert`"))
+	case rule.Record != "" && !prometheusMetricNameRegex.MatchString(rule.Record):
+		errs = append(errs, fmt.Errorf("records `%s`, which is not a valid metric name", rule.Record))
+	case rule.Record != "" && (rule.For != "" || rule.KeepFiringFor != "" || len(rule.Annotations) > 0):
+		errs = append(errs, fmt.Errorf("is a recording rule, which can't set `for`, `keep_firing_for` or `annotations`"))
+	}
+
+	if rule.Expr == "" {
+		errs = append(errs, fmt.Errorf("has no `expr`"))
+	} else if err := validateExpr(rule.Expr); err != nil {
+		errs = append(errs, fmt.Errorf("has an invalid `expr`: %v", err))
+	}
+
+	if rule.For != "" && !isPrometheusDuration(rule.For) {
+		errs = append(errs, fmt.Errorf("has `for` set to `%s`, which is not a valid duration", rule.For))
+	}
+
+	if rule.KeepFiringFor != "" && !isPrometheusDuration(rule.KeepFiringFor) {
+		errs = append(errs, fmt.Errorf("has `keep_firing_for` set to `%s`, which is not a valid duration", rule.KeepFiringFor))
+	}
+
+	for name := range rule.Labels {
+		if !prometheusLabelNameRegex.MatchString(name) {
+			errs = append(errs, fmt.Errorf("has label `%s`, which is not a valid label name", name))
+		}
+	}
+
+	return errs
+}
+
+func isPrometheusDuration(s string) bool {
+	return s != "" && prometheusDurationRegex.MatchString(s)
+}
+
+func ruleNamespaceCreate(getClient rulerClientGetter) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		p := m.(*Provider)
+
+		stack := d.Get("stack").(string)
+		client, err := getClient(p.Client, ctx, p.organisation(d), stack)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		name := d.Get("name").(string)
+		existing, err := client.ListRuleGroups(ctx, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Rule groups would otherwise be added to the existing namespace
+		if existing != nil {
+			return diag.Errorf("rule namespace `%s` already exists, import it instead", name)
+		}
+
+		if err := setRuleNamespaceContent(ctx, client, name, nil, d.Get("content").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(compositeID(stack, name))
+
+		return ruleNamespaceRead(getClient)(ctx, d, m)
+	}
+}
+
+func ruleNamespaceRead(getClient rulerClientGetter) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		var diags diag.Diagnostics
+		p := m.(*Provider)
+
+		if err := d.Set("organisation", p.organisation(d)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		stack, name, err := splitCompositeID(d.Id(), "stack/name")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		client, err := getClient(p.Client, ctx, p.organisation(d), stack)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		groups, err := client.ListRuleGroups(ctx, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if groups == nil {
+			d.SetId("")
+			return diags
+		}
+
+		content, err := marshalRuleNamespaceContent(&ruleNamespaceContent{Groups: groups})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("stack", stack); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("name", name); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("content", content); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diags
+	}
+}
+
+func ruleNamespaceUpdate(getClient rulerClientGetter) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		p := m.(*Provider)
+
+		stack, name, err := splitCompositeID(d.Id(), "stack/name")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		client, err := getClient(p.Client, ctx, p.organisation(d), stack)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		existing, err := client.ListRuleGroups(ctx, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := setRuleNamespaceContent(ctx, client, name, existing, d.Get("content").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return ruleNamespaceRead(getClient)(ctx, d, m)
+	}
+}
+
+func ruleNamespaceDelete(getClient rulerClientGetter) schema.DeleteContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		var diags diag.Diagnostics
+		p := m.(*Provider)
+
+		stack, name, err := splitCompositeID(d.Id(), "stack/name")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		client, err := getClient(p.Client, ctx, p.organisation(d), stack)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = client.DeleteNamespace(ctx, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId("")
+		return diags
+	}
+}
+
+// Saves all configured rule groups and deletes the existing ones which aren't configured anymore.
+// The ruler only accepts single rule groups, so the namespace is updated group by group.
+func setRuleNamespaceContent(ctx context.Context, client *ruler.Client, namespace string, existing []*ruler.RuleGroup, content string) error {
+	parsed, err := parseRuleNamespaceContent(content, false)
+	if err != nil {
+		return err
+	}
+
+	configured := make(map[string]bool)
+	for _, group := range parsed.Groups {
+		configured[group.Name] = true
+		if err := client.SetRuleGroup(ctx, namespace, group); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range existing {
+		if !configured[group.Name] {
+			if err := client.DeleteRuleGroup(ctx, namespace, group.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Unknown fields are only rejected when strict, so the ruler can return fields not known to the
+// provider.
+func parseRuleNamespaceContent(content string, strict bool) (*ruleNamespaceContent, error) {
+	result := &ruleNamespaceContent{}
+
+	decoder := yaml.NewDecoder(bytes.NewBufferString(content))
+	decoder.KnownFields(strict)
+	if err := decoder.Decode(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func marshalRuleNamespaceContent(content *ruleNamespaceContent) (string, error) {
+	sortRuleGroups(content)
+	return marshalYAML(content)
+}
+
+// The ruler doesn't keep the order of groups
+func sortRuleGroups(content *ruleNamespaceContent) {
+	sort.Slice(content.Groups, func(i, j int) bool {
+		return content.Groups[i].Name < content.Groups[j].Name
+	})
+}
+
+// Normalises the content, so only actual changes to the rules result in a diff.
+func normalizeRuleNamespaceContent(content interface{}) string {
+	return normalizeYAML(content, func(content string) (interface{}, error) {
+		parsed, err := parseRuleNamespaceContent(content, false)
+		if err != nil {
+			return nil, err
+		}
+
+		sortRuleGroups(parsed)
+		return parsed, nil
+	})
+}
@@ -2,19 +2,36 @@ package grafanacloud
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
 )
 
+var (
+	stackRegions = []string{"us", "us-azure", "eu", "au", "prod-ap-southeast-0", "prod-gb-south-0"}
+)
+
 func resourceStack() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Manages a single Stack in Grafana Cloud.",
 		CreateContext: resourceStackCreate,
 		ReadContext:   resourceStackRead,
+		UpdateContext: resourceStackUpdate,
 		DeleteContext: resourceStackDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStackImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
@@ -24,7 +41,6 @@ func resourceStack() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "Name of the Grafana Cloud stack.",
 			},
 			"slug": {
@@ -36,21 +52,54 @@ func resourceStack() *schema.Resource {
 			"url": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Computed:    true,
 				Description: "Custom URL for the Grafana instance. Must have a CNAME setup to point to `.grafana.net` before creating the stack.",
 			},
+			"region_slug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Computed:     true,
+				Description:  fmt.Sprintf("Region the stack is deployed to. Might be one of %s. Defaults to the region chosen by Grafana Cloud if not set.", stackRegions),
+				ValidateFunc: ValidateStackRegion(),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the Grafana Cloud stack.",
+			},
+			"labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Labels to attach to the Grafana Cloud stack, e.g. to identify the owning team.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"delete_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether or not the stack is protected from being deleted. Deleting a stack also deletes all of its data, so this needs to be set to `false` and applied before the stack can be destroyed.",
+			},
 		},
 	}
 }
 
+func ValidateStackRegion() schema.SchemaValidateFunc {
+	return validation.StringInSlice(stackRegions, false)
+}
+
 func resourceStackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	p := m.(*Provider)
 
 	req := &portal.CreateStackInput{
-		Name: d.Get("name").(string),
-		Slug: d.Get("slug").(string),
-		URL:  d.Get("url").(string),
+		Name:        d.Get("name").(string),
+		Slug:        d.Get("slug").(string),
+		URL:         d.Get("url").(string),
+		Region:      d.Get("region_slug").(string),
+		Description: d.Get("description").(string),
+		Labels:      expandStringMap(d.Get("labels").(map[string]interface{})),
+		Org:         d.Get("organisation").(string),
 	}
 
 	resp, err := p.Client.CreateStack(ctx, req)
@@ -60,6 +109,10 @@ func resourceStackCreate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	d.SetId(strconv.Itoa(resp.ID))
 
+	if err := waitForStackActive(ctx, p, p.organisation(d), resp.Slug, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("stack `%s` was created but didn't become ready: %v", resp.Slug, err)
+	}
+
 	return resourceStackRead(ctx, d, m)
 }
 
@@ -67,12 +120,21 @@ func resourceStackRead(ctx context.Context, d *schema.ResourceData, m interface{
 	var diags diag.Diagnostics
 	p := m.(*Provider)
 
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	slug := d.Get("slug").(string)
-	resp, err := p.Client.GetStack(ctx, p.Organisation, slug)
+	resp, err := p.Client.GetStack(ctx, p.organisation(d), slug)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if resp == nil {
+		d.SetId("")
+		return diags
+	}
+
 	if err := d.Set("name", resp.Name); err != nil {
 		return diag.FromErr(err)
 	}
@@ -85,19 +147,169 @@ func resourceStackRead(ctx context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("region_slug", resp.RegionSlug); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("description", resp.Description); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("labels", resp.Labels); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
+func resourceStackUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	req := &portal.UpdateStackInput{
+		Name:        d.Get("name").(string),
+		Slug:        d.Get("slug").(string),
+		Description: d.Get("description").(string),
+		Labels:      expandStringMap(d.Get("labels").(map[string]interface{})),
+	}
+
+	if d.HasChange("url") {
+		req.URL = d.Get("url").(string)
+	}
+
+	// Delete protection is only known to this provider, so there's nothing to update if that's all that changed
+	if !d.HasChangesExcept("delete_protection") {
+		return resourceStackRead(ctx, d, m)
+	}
+
+	_, err := p.Client.UpdateStack(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStackRead(ctx, d, m)
+}
+
 func resourceStackDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	p := m.(*Provider)
 
 	slug := d.Get("slug").(string)
+	if d.Get("delete_protection").(bool) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Stack `%s` is protected from deletion", slug),
+				Detail:   "Deleting a stack also deletes all of its data. If you really want to delete it, set `delete_protection = false` and apply that change first.",
+			},
+		}
+	}
+
 	err := p.Client.DeleteStack(ctx, slug)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := waitForStackDeleted(ctx, p, p.organisation(d), slug, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("stack `%s` wasn't deleted: %v", slug, err)
+	}
+
 	d.SetId("")
 	return diags
 }
+
+// Newly created stacks take a while to be provisioned. Until then, the Grafana instance inside them
+// doesn't accept any requests, so block until the stack reports itself as active.
+func waitForStackActive(ctx context.Context, p *Provider, org, slug string, timeout time.Duration) error {
+	conf := &resource.StateChangeConf{
+		Pending: []string{portal.StackStatusStarting, portal.StackStatusCreating},
+		Target:  []string{portal.StackStatusActive},
+		Refresh: stackStatusRefreshFunc(ctx, p, org, slug),
+		Timeout: timeout,
+	}
+
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
+
+func waitForStackDeleted(ctx context.Context, p *Provider, org, slug string, timeout time.Duration) error {
+	conf := &resource.StateChangeConf{
+		Pending: []string{portal.StackStatusActive, portal.StackStatusDeleting},
+		Target:  []string{},
+		Refresh: stackStatusRefreshFunc(ctx, p, org, slug),
+		Timeout: timeout,
+	}
+
+	_, err := conf.WaitForStateContext(ctx)
+	return err
+}
+
+func stackStatusRefreshFunc(ctx context.Context, p *Provider, org, slug string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		stack, err := p.Client.GetStack(ctx, org, slug)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if stack == nil || stack.Status == portal.StackStatusDeleted {
+			return nil, "", nil
+		}
+
+		if stack.Status == portal.StackStatusFailed {
+			return stack, stack.Status, fmt.Errorf("provisioning of stack `%s` failed", slug)
+		}
+
+		return stack, stack.Status, nil
+	}
+}
+
+func expandStringMap(m map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v.(string)
+	}
+
+	return result
+}
+
+// Stacks are imported by their slug, since that's what users know them by. The numeric
+// ID is looked up through the Grafana Cloud API. Stacks of other organisations than the one
+// of the provider are imported as `organisation/slug`.
+func resourceStackImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	p := m.(*Provider)
+
+	slug := d.Id()
+	if strings.Contains(slug, "/") {
+		org, s, err := splitCompositeID(slug, "organisation/slug")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := d.Set("organisation", org); err != nil {
+			return nil, err
+		}
+
+		slug = s
+	}
+
+	stack, err := p.Client.GetStack(ctx, p.organisation(d), slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if stack == nil {
+		return nil, fmt.Errorf("couldn't find stack with slug `%s`", slug)
+	}
+
+	d.SetId(strconv.Itoa(stack.ID))
+
+	if err := d.Set("slug", stack.Slug); err != nil {
+		return nil, err
+	}
+
+	// Imported stacks are protected from deletion, same as newly created ones
+	if err := d.Set("delete_protection", true); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
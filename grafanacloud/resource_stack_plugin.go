@@ -0,0 +1,159 @@
+package grafanacloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+)
+
+func resourceStackPlugin() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single plugin installed on a Grafana Cloud stack.",
+		CreateContext: resourceStackPluginCreate,
+		ReadContext:   resourceStackPluginRead,
+		UpdateContext: resourceStackPluginUpdate,
+		DeleteContext: resourceStackPluginDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the plugin installation in Terraform, composed as `stack/slug`.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack to install the plugin on.",
+			},
+			"slug": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Slug of the plugin, e.g. `grafana-clock-panel`. See https://grafana.com/grafana/plugins/ for available plugins.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Version of the plugin. Defaults to the latest version at the time the plugin is installed.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the plugin.",
+			},
+			"latest_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Latest available version of the plugin.",
+			},
+		},
+	}
+}
+
+func resourceStackPluginCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	req := &portal.InstallStackPluginInput{
+		Plugin:  d.Get("slug").(string),
+		Version: d.Get("version").(string),
+		Stack:   d.Get("stack").(string),
+	}
+
+	_, err := p.Client.InstallStackPlugin(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(compositeID(req.Stack, req.Plugin))
+
+	return resourceStackPluginRead(ctx, d, m)
+}
+
+func resourceStackPluginRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, slug, err := splitCompositeID(d.Id(), "stack/slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := p.Client.ListStackPlugins(ctx, stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	plugin := resp.FindBySlug(slug)
+	if plugin == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("slug", plugin.PluginSlug); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("version", plugin.Version); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", plugin.PluginName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("latest_version", plugin.LatestVersion); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceStackPluginUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack, slug, err := splitCompositeID(d.Id(), "stack/slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &portal.UpdateStackPluginInput{
+		Version: d.Get("version").(string),
+		Stack:   stack,
+		Plugin:  slug,
+	}
+
+	_, err = p.Client.UpdateStackPlugin(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStackPluginRead(ctx, d, m)
+}
+
+func resourceStackPluginDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, slug, err := splitCompositeID(d.Id(), "stack/slug")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = p.Client.UninstallStackPlugin(ctx, stack, slug)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
@@ -0,0 +1,161 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStackPlugin_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackPluginDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackPluginConfig(resourceName, "1.0.0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackPluginExists("grafanacloud_stack_plugin.test", "1.0.0"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_plugin.test", "id", resourceName+"slug/grafana-clock-panel"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_plugin.test", "stack", resourceName+"slug"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_plugin.test", "slug", "grafana-clock-panel"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_plugin.test", "version", "1.0.0"),
+					resource.TestCheckResourceAttrSet("grafanacloud_stack_plugin.test", "name"),
+					resource.TestCheckResourceAttrSet("grafanacloud_stack_plugin.test", "latest_version"),
+				),
+			},
+			{
+				Config: testAccStackPluginConfig(resourceName, "1.1.0"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackPluginExists("grafanacloud_stack_plugin.test", "1.1.0"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_plugin.test", "version", "1.1.0"),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_stack_plugin.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccStackPlugin_LatestVersion(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackPluginDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackPluginConfigLatest(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackPluginExists("grafanacloud_stack_plugin.test", "2.0.0"),
+					resource.TestCheckResourceAttrPair("grafanacloud_stack_plugin.test", "version", "grafanacloud_stack_plugin.test", "latest_version"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStackPluginExists(resourceName, version string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		res, err := p.Client.ListStackPlugins(ctx, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		plugin := res.FindBySlug(rs.Primary.Attributes["slug"])
+		if plugin == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		if plugin.Version != version {
+			return fmt.Errorf("resource `%s` has version `%s` installed, expected `%s`", resourceName, plugin.Version, version)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckStackPluginDestroy(s *terraform.State) error {
+	ctx := context.Background()
+	p := getProvider(testAccProvider)
+
+	for name, rs := range s.RootModule().Resources {
+		if rs.Type != "grafanacloud_stack_plugin" {
+			continue
+		}
+
+		parts := strings.SplitN(rs.Primary.ID, "/", 2)
+		stack, err := p.Client.GetStack(ctx, p.Organisation, parts[0])
+		if err != nil {
+			return err
+		}
+
+		// Plugins are gone together with their stack
+		if stack == nil {
+			continue
+		}
+
+		res, err := p.Client.ListStackPlugins(ctx, parts[0])
+		if err != nil {
+			return err
+		}
+
+		if res.FindBySlug(parts[1]) != nil {
+			return fmt.Errorf("resource `%s` with ID `%s` still exists after destroy", name, rs.Primary.ID)
+		}
+	}
+
+	return testAccCheckStackDestroy(s)
+}
+
+func testAccStackPluginConfig(resourceName, version string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack_plugin" "test" {
+  stack   = grafanacloud_stack.test.slug
+  slug    = "grafana-clock-panel"
+  version = "%s"
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, version, resourceName, resourceName)
+}
+
+func testAccStackPluginConfigLatest(resourceName string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack_plugin" "test" {
+  stack = grafanacloud_stack.test.slug
+  slug  = "grafana-clock-panel"
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%sslug"
+  delete_protection = false
+}
+`, resourceName, resourceName)
+}
@@ -0,0 +1,210 @@
+package grafanacloud
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+func resourceStackServiceAccount() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single service account on a Grafana instance inside a Grafana Cloud stack. Service accounts replace the deprecated Grafana API keys.",
+		CreateContext: resourceStackServiceAccountCreate,
+		ReadContext:   resourceStackServiceAccountRead,
+		UpdateContext: resourceStackServiceAccountUpdate,
+		DeleteContext: resourceStackServiceAccountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importWithOrganisation(2),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the service account in Terraform, composed as `stack/service_account_id`.",
+			},
+			"service_account_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the service account in Grafana.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack to create this service account in.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the service account.",
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  fmt.Sprintf("Role of the service account. Might be one of %s.", grafanaApiKeyRoles),
+				ValidateFunc: ValidateGrafanaApiKeyRole(),
+			},
+			"is_disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether or not the service account is disabled.",
+			},
+			"login": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Login of the service account, generated by Grafana.",
+			},
+		},
+	}
+}
+
+func resourceStackServiceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.CreateServiceAccountInput{
+		Name:       d.Get("name").(string),
+		Role:       d.Get("role").(string),
+		IsDisabled: d.Get("is_disabled").(bool),
+	}
+
+	resp, err := client.CreateServiceAccount(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(compositeID(stack, strconv.Itoa(resp.ID)))
+
+	return resourceStackServiceAccountRead(ctx, d, m)
+}
+
+func resourceStackServiceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack, id, err := splitServiceAccountID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serviceAccount, err := client.GetServiceAccount(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if serviceAccount == nil {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("service_account_id", serviceAccount.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("stack", stack); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", serviceAccount.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("role", serviceAccount.Role); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("is_disabled", serviceAccount.IsDisabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("login", serviceAccount.Login); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceStackServiceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack, id, err := splitServiceAccountID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.UpdateServiceAccountInput{
+		Name:       d.Get("name").(string),
+		Role:       d.Get("role").(string),
+		IsDisabled: d.Get("is_disabled").(bool),
+		ID:         id,
+	}
+
+	_, err = client.UpdateServiceAccount(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStackServiceAccountRead(ctx, d, m)
+}
+
+func resourceStackServiceAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, id, err := splitServiceAccountID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteServiceAccount(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func splitServiceAccountID(id string) (string, int, error) {
+	stack, serviceAccountID, err := splitCompositeID(id, "stack/service_account_id")
+	if err != nil {
+		return "", 0, err
+	}
+
+	numericID, err := strconv.Atoi(serviceAccountID)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid service account ID `%s`, expected a number: %v", serviceAccountID, err)
+	}
+
+	return stack, numericID, nil
+}
@@ -0,0 +1,104 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStackServiceAccount_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		// Service accounts are gone together with the Grafana instance of their stack
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackServiceAccountConfig(resourceName, "Viewer", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackServiceAccountExists("grafanacloud_stack_service_account.test"),
+					resource.TestCheckResourceAttrSet("grafanacloud_stack_service_account.test", "id"),
+					resource.TestCheckResourceAttrSet("grafanacloud_stack_service_account.test", "service_account_id"),
+					resource.TestCheckResourceAttrSet("grafanacloud_stack_service_account.test", "login"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account.test", "name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account.test", "stack", "dummystack"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account.test", "role", "Viewer"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account.test", "is_disabled", "false"),
+				),
+			},
+			{
+				Config: testAccStackServiceAccountConfig(resourceName, "Admin", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackServiceAccountExists("grafanacloud_stack_service_account.test"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account.test", "role", "Admin"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account.test", "is_disabled", "true"),
+				),
+			},
+			{
+				ResourceName:      "grafanacloud_stack_service_account.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckStackServiceAccountExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		id, err := strconv.Atoi(rs.Primary.Attributes["service_account_id"])
+		if err != nil {
+			return err
+		}
+
+		serviceAccount, err := gc.GetServiceAccount(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if serviceAccount == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		return nil
+	}
+}
+
+func testAccStackServiceAccountConfig(resourceName, role string, isDisabled bool) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack_service_account" "test" {
+  name = "%s"
+  role = "%s"
+  is_disabled = %t
+  stack = grafanacloud_stack.test.slug
+}
+
+resource "grafanacloud_stack" "test" {
+  name = "dummy-stack"
+  slug = "dummystack"
+  delete_protection = false
+}
+`, resourceName, role, isDisabled)
+}
@@ -0,0 +1,194 @@
+package grafanacloud
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+func resourceStackServiceAccountToken() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a single token of a service account on a Grafana instance inside a Grafana Cloud stack. Notice that the token value will be stored in Terraform state, so make sure to manage your Terraform state safely (see https://www.terraform.io/docs/language/state/sensitive-data.html).",
+		CreateContext: resourceStackServiceAccountTokenCreate,
+		ReadContext:   resourceStackServiceAccountTokenRead,
+		DeleteContext: resourceStackServiceAccountTokenDelete,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the token in Terraform, composed as `stack/token_id`.",
+			},
+			"stack": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Grafana Cloud stack the service account belongs to.",
+			},
+			"organisation": resourceOrganisationSchema(),
+			"service_account_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the service account to create the token for (`service_account_id` of `grafanacloud_stack_service_account`).",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the token.",
+			},
+			"seconds_to_live": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Time in seconds after which the token automatically expires",
+			},
+			"expiration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time at which the token expires (ISO8601 format). Blank if the token never expires.",
+			},
+			"is_expired": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether or not the token has expired. This field is used internally in order to recreate expired tokens. Set this to `true` to not recreate expired tokens.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The generated token.",
+			},
+		},
+	}
+}
+
+func resourceStackServiceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p := m.(*Provider)
+
+	stack := d.Get("stack").(string)
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &grafana.CreateServiceAccountTokenInput{
+		Name:             d.Get("name").(string),
+		SecondsToLive:    d.Get("seconds_to_live").(int),
+		ServiceAccountID: d.Get("service_account_id").(int),
+	}
+
+	resp, err := client.CreateServiceAccountToken(ctx, req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("key", resp.Key)
+	d.SetId(compositeID(stack, strconv.Itoa(resp.ID)))
+
+	return resourceStackServiceAccountTokenRead(ctx, d, m)
+}
+
+func resourceStackServiceAccountTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	if err := d.Set("organisation", p.organisation(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	stack, id, err := splitServiceAccountTokenID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Tokens are deleted together with their service account
+	serviceAccountID := d.Get("service_account_id").(int)
+	serviceAccount, err := client.GetServiceAccount(ctx, serviceAccountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if serviceAccount == nil {
+		d.SetId("")
+		return diags
+	}
+
+	tokens, err := client.ListServiceAccountTokens(ctx, serviceAccountID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	token := tokens.FindByID(id)
+	if token == nil {
+		d.SetId("")
+		return diags
+	}
+
+	expired, err := token.IsExpired()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("name", token.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("expiration", token.Expiration); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("is_expired", expired); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceStackServiceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	p := m.(*Provider)
+
+	stack, id, err := splitServiceAccountTokenID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client, err := p.Client.GetAuthedGrafanaClient(ctx, p.organisation(d), stack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteServiceAccountToken(ctx, d.Get("service_account_id").(int), id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func splitServiceAccountTokenID(id string) (string, int, error) {
+	stack, tokenID, err := splitCompositeID(id, "stack/token_id")
+	if err != nil {
+		return "", 0, err
+	}
+
+	numericID, err := strconv.Atoi(tokenID)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid service account token ID `%s`, expected a number: %v", tokenID, err)
+	}
+
+	return stack, numericID, nil
+}
@@ -0,0 +1,156 @@
+package grafanacloud_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccStackServiceAccountToken_Basic(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackServiceAccountTokenConfig(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackServiceAccountTokenExists("grafanacloud_stack_service_account_token.test"),
+					resource.TestCheckResourceAttrSet("grafanacloud_stack_service_account_token.test", "id"),
+					resource.TestCheckResourceAttrSet("grafanacloud_stack_service_account_token.test", "key"),
+					resource.TestCheckResourceAttrPair("grafanacloud_stack_service_account_token.test", "service_account_id", "grafanacloud_stack_service_account.test", "service_account_id"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account_token.test", "name", resourceName),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account_token.test", "stack", "dummystack"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account_token.test", "expiration", ""),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account_token.test", "is_expired", "false"),
+					resource.TestCheckNoResourceAttr("grafanacloud_stack_service_account_token.test", "seconds_to_live"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStackServiceAccountToken_Expiring(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackServiceAccountTokenConfigExpiring(resourceName, 10),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackServiceAccountTokenExists("grafanacloud_stack_service_account_token.test"),
+					resource.TestMatchResourceAttr("grafanacloud_stack_service_account_token.test", "expiration", regexp.MustCompile("^\\d{4}-\\d{2}-\\d{2}T\\d{2}:\\d{2}:\\d{2}.*$")),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account_token.test", "is_expired", "false"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account_token.test", "seconds_to_live", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStackServiceAccountToken_Expired(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackServiceAccountTokenConfigExpiring(resourceName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackServiceAccountTokenExists("grafanacloud_stack_service_account_token.test"),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account_token.test", "is_expired", "false"),
+				),
+			},
+			{
+				Config: testAccStackServiceAccountTokenConfigExpiring(resourceName, 2),
+				// This is supposed to recreate the now expired token
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					testAccSleep(3*time.Second),
+					resource.TestCheckResourceAttr("grafanacloud_stack_service_account_token.test", "is_expired", "false"),
+				),
+			},
+			{
+				Config: testAccStackServiceAccountTokenConfigExpiring(resourceName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackServiceAccountTokenExists("grafanacloud_stack_service_account_token.test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStackServiceAccountTokenExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource `%s` has no ID set", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		gc, err := p.Client.GetAuthedGrafanaClient(ctx, p.Organisation, rs.Primary.Attributes["stack"])
+		if err != nil {
+			return err
+		}
+
+		serviceAccountID, err := strconv.Atoi(rs.Primary.Attributes["service_account_id"])
+		if err != nil {
+			return err
+		}
+
+		res, err := gc.ListServiceAccountTokens(ctx, serviceAccountID)
+		if err != nil {
+			return err
+		}
+
+		id, err := strconv.Atoi(strings.SplitN(rs.Primary.ID, "/", 2)[1])
+		if err != nil {
+			return err
+		}
+
+		if res.FindByID(id) == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		return nil
+	}
+}
+
+func testAccStackServiceAccountTokenConfig(resourceName string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack_service_account_token" "test" {
+  name = "%s"
+  stack = grafanacloud_stack.test.slug
+  service_account_id = grafanacloud_stack_service_account.test.service_account_id
+}
+`, resourceName) + testAccStackServiceAccountConfig(resourceName, "Viewer", false)
+}
+
+func testAccStackServiceAccountTokenConfigExpiring(resourceName string, secondsToLive int) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack_service_account_token" "test" {
+  name = "%s"
+  stack = grafanacloud_stack.test.slug
+  service_account_id = grafanacloud_stack_service_account.test.service_account_id
+  seconds_to_live = %d
+}
+`, resourceName, secondsToLive) + testAccStackServiceAccountConfig(resourceName, "Viewer", false)
+}
@@ -3,14 +3,47 @@ package grafanacloud_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/grafanacloud"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+	"github.com/stretchr/testify/require"
 )
 
+func TestValidateStackRegion(t *testing.T) {
+	fn := grafanacloud.ValidateStackRegion()
+
+	var tests = []struct {
+		region string
+		valid  bool
+	}{
+		{"us", true},
+		{"eu", true},
+		{"au", true},
+		{"prod-gb-south-0", true},
+		{"EU", false},
+		{"mars", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			warn, err := fn(tt.region, "region_slug")
+			if tt.valid {
+				require.Empty(t, warn)
+				require.Empty(t, err)
+			} else {
+				require.Empty(t, warn)
+				require.NotEmpty(t, err)
+			}
+		})
+	}
+}
+
 func TestAccStack_Basic(t *testing.T) {
 	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 	urlRegexpString := fmt.Sprintf("http://.+/grafana/%s-slug", resourceName)
@@ -23,12 +56,85 @@ func TestAccStack_Basic(t *testing.T) {
 				Config: testAccStackConfig(resourceName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckStackExists("grafanacloud_stack.test"),
+					testAccCheckStackStatus("grafanacloud_stack.test", portal.StackStatusActive),
 					resource.TestCheckResourceAttrSet("grafanacloud_stack.test", "id"),
 					resource.TestCheckResourceAttr("grafanacloud_stack.test", "name", resourceName),
 					resource.TestCheckResourceAttr("grafanacloud_stack.test", "slug", resourceName+"-slug"),
 					resource.TestMatchResourceAttr("grafanacloud_stack.test", "url", regexp.MustCompile(urlRegexpString)),
 				),
 			},
+			{
+				ResourceName:      "grafanacloud_stack.test",
+				ImportState:       true,
+				ImportStateId:     resourceName + "-slug",
+				ImportStateVerify: true,
+				// Imported stacks are always protected from deletion
+				ImportStateVerifyIgnore: []string{"delete_protection"},
+			},
+		},
+	})
+}
+
+func TestAccStack_Organisation(t *testing.T) {
+	org := os.Getenv(EnvOtherOrganisation)
+	if org == "" {
+		t.Skipf("%s must be set to test managing stacks of other organisations", EnvOtherOrganisation)
+	}
+
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfigOrganisation(resourceName, org),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackExists("grafanacloud_stack.test"),
+					testAccCheckStackNotInProviderOrganisation("grafanacloud_stack.test"),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "organisation", org),
+					resource.TestCheckResourceAttrPair("data.grafanacloud_stack.test", "id", "grafanacloud_stack.test", "id"),
+					resource.TestCheckResourceAttrSet("grafanacloud_folder.test", "uid"),
+				),
+			},
+			{
+				ResourceName:            "grafanacloud_stack.test",
+				ImportState:             true,
+				ImportStateId:           fmt.Sprintf("%s/%s-slug", org, resourceName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_protection"},
+			},
+		},
+	})
+}
+
+func TestAccStack_ProviderOrganisation(t *testing.T) {
+	org := os.Getenv(grafanacloud.EnvOrganisation)
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfig(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackExists("grafanacloud_stack.test"),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "organisation", org),
+				),
+			},
+			{
+				// Setting the organisation of the provider explicitly doesn't replace anything
+				Config: fmt.Sprintf(`
+resource "grafanacloud_stack" "test" {
+  name = "%[1]s"
+  slug = "%[1]s-slug"
+  organisation = "%[2]s"
+  delete_protection = false
+}
+`, resourceName, org),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -55,6 +161,140 @@ func TestAccStack_URL(t *testing.T) {
 	})
 }
 
+func TestAccStack_Update(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	newName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	url := "https://my.grafana.instance"
+	var stackID string
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfig(resourceName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackExists("grafanacloud_stack.test"),
+					testAccStoreStackID("grafanacloud_stack.test", &stackID),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "name", resourceName),
+				),
+			},
+			{
+				Config: testAccStackConfigNameURL(newName, resourceName, url),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackExists("grafanacloud_stack.test"),
+					testAccCheckStackNotRecreated("grafanacloud_stack.test", &stackID),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "name", newName),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "slug", resourceName+"-slug"),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "url", url),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStack_RegionDescriptionLabels(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	var stackID string
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfigRegionDescriptionLabels(resourceName, "eu", "Owned by team A", "team-a"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackExists("grafanacloud_stack.test"),
+					testAccStoreStackID("grafanacloud_stack.test", &stackID),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "region_slug", "eu"),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "description", "Owned by team A"),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "labels.%", "1"),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "labels.team", "team-a"),
+				),
+			},
+			{
+				Config: testAccStackConfigRegionDescriptionLabels(resourceName, "eu", "Owned by team B", "team-b"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackNotRecreated("grafanacloud_stack.test", &stackID),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "description", "Owned by team B"),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "labels.team", "team-b"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStack_InvalidRegion(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccStackConfigRegionDescriptionLabels(resourceName, "mars", "Owned by team A", "team-a"),
+				ExpectError: regexp.MustCompile(`expected region_slug to be one of`),
+			},
+		},
+	})
+}
+
+func TestAccStack_DeleteProtection(t *testing.T) {
+	resourceName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStackDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStackConfigDeleteProtection(resourceName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackExists("grafanacloud_stack.test"),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "delete_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccStackConfigDeleteProtection(resourceName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("is protected from deletion"),
+			},
+			{
+				Config: testAccStackConfigDeleteProtection(resourceName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStackExists("grafanacloud_stack.test"),
+					resource.TestCheckResourceAttr("grafanacloud_stack.test", "delete_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccStoreStackID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckStackNotRecreated(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("resource `%s` was recreated: ID changed from `%s` to `%s`", resourceName, *id, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckStackExists(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		ctx := context.Background()
@@ -69,7 +309,7 @@ func testAccCheckStackExists(resourceName string) resource.TestCheckFunc {
 		}
 
 		p := getProvider(testAccProvider)
-		stack, err := p.Client.GetStack(ctx, p.Organisation, rs.Primary.Attributes["slug"])
+		stack, err := p.Client.GetStack(ctx, testAccOrganisation(rs), rs.Primary.Attributes["slug"])
 		if err != nil {
 			return err
 		}
@@ -82,6 +322,54 @@ func testAccCheckStackExists(resourceName string) resource.TestCheckFunc {
 	}
 }
 
+func testAccCheckStackNotInProviderOrganisation(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		stack, err := p.Client.GetStack(context.Background(), p.Organisation, rs.Primary.Attributes["slug"])
+		if err != nil {
+			return err
+		}
+
+		if stack != nil {
+			return fmt.Errorf("resource `%s` found in organisation `%s` of the provider", resourceName, p.Organisation)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckStackStatus(resourceName, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource `%s` not found", resourceName)
+		}
+
+		p := getProvider(testAccProvider)
+		stack, err := p.Client.GetStack(ctx, testAccOrganisation(rs), rs.Primary.Attributes["slug"])
+		if err != nil {
+			return err
+		}
+
+		if stack == nil {
+			return fmt.Errorf("resource `%s` not found via API", resourceName)
+		}
+
+		if stack.Status != status {
+			return fmt.Errorf("resource `%s` has status `%s`, expected `%s`", resourceName, stack.Status, status)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckStackDestroy(s *terraform.State) error {
 	ctx := context.Background()
 	p := getProvider(testAccProvider)
@@ -91,7 +379,7 @@ func testAccCheckStackDestroy(s *terraform.State) error {
 			continue
 		}
 
-		stack, err := p.Client.GetStack(ctx, p.Organisation, rs.Primary.Attributes["slug"])
+		stack, err := p.Client.GetStack(ctx, testAccOrganisation(rs), rs.Primary.Attributes["slug"])
 		if err != nil {
 			return err
 		}
@@ -104,21 +392,92 @@ func testAccCheckStackDestroy(s *terraform.State) error {
 	return nil
 }
 
+// Returns the organisation of the resource, which defaults to the one of the provider.
+func testAccOrganisation(rs *terraform.ResourceState) string {
+	if org := rs.Primary.Attributes["organisation"]; org != "" {
+		return org
+	}
+
+	return getProvider(testAccProvider).Organisation
+}
+
 func testAccStackConfig(resourceName string) string {
 	return fmt.Sprintf(`
 resource "grafanacloud_stack" "test" {
   name = "%s"
   slug = "%s-slug"
+  delete_protection = false
 }
 `, resourceName, resourceName)
 }
 
+func testAccStackConfigOrganisation(resourceName, org string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack" "test" {
+  name = "%[1]s"
+  slug = "%[1]s-slug"
+  organisation = "%[2]s"
+  delete_protection = false
+}
+
+data "grafanacloud_stack" "test" {
+  slug = grafanacloud_stack.test.slug
+  organisation = grafanacloud_stack.test.organisation
+}
+
+resource "grafanacloud_folder" "test" {
+  stack = grafanacloud_stack.test.slug
+  organisation = grafanacloud_stack.test.organisation
+  title = "%[1]s"
+}
+`, resourceName, org)
+}
+
 func testAccStackConfigURL(resourceName, url string) string {
 	return fmt.Sprintf(`
 resource "grafanacloud_stack" "test" {
   name = "%s"
   slug = "%s-slug"
-	url  = "%s"
+  url  = "%s"
+  delete_protection = false
 }
 `, resourceName, resourceName, url)
 }
+
+func testAccStackConfigNameURL(name, slug, url string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack" "test" {
+  name = "%s"
+  slug = "%s-slug"
+  url  = "%s"
+  delete_protection = false
+}
+`, name, slug, url)
+}
+
+func testAccStackConfigRegionDescriptionLabels(resourceName, region, description, team string) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack" "test" {
+  name        = "%s"
+  slug        = "%s-slug"
+  region_slug = "%s"
+  description = "%s"
+
+  labels = {
+    team = "%s"
+  }
+
+  delete_protection = false
+}
+`, resourceName, resourceName, region, description, team)
+}
+
+func testAccStackConfigDeleteProtection(resourceName string, deleteProtection bool) string {
+	return fmt.Sprintf(`
+resource "grafanacloud_stack" "test" {
+  name              = "%s"
+  slug              = "%s-slug"
+  delete_protection = %t
+}
+`, resourceName, resourceName, deleteProtection)
+}
@@ -0,0 +1,67 @@
+package alertmanager
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+// Client for the Cortex-compatible configuration API of the Alertmanager instance of a stack. This
+// authenticates with the user ID of the Alertmanager instance and a Grafana Cloud API key.
+type Client struct {
+	client *resty.Client
+
+	throttle *util.Throttle
+}
+
+type ClientOpt func(*Client)
+
+func NewClient(baseURL, userID, apiKey string, opts ...ClientOpt) (*Client, error) {
+	url := baseURL
+
+	if !strings.HasSuffix(url, "/") {
+		url = url + "/"
+	}
+
+	resty := resty.New().
+		SetDebug(len(os.Getenv("HTTP_DEBUG")) != 0).
+		SetBasicAuth(userID, apiKey).
+		SetHostURL(url).
+		SetTimeout(30 * time.Second).
+		SetRetryCount(6).
+		AddRetryCondition(util.IsRateLimited)
+
+	c := &Client{
+		client: resty,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.throttle == nil {
+		c.throttle = util.NewThrottle(fmt.Sprintf("Alertmanager API at `%s`", url), 0, 0)
+	}
+
+	c.throttle.Apply(c.client)
+
+	return c, nil
+}
+
+func WithUserAgent(userAgent string) ClientOpt {
+	return func(c *Client) {
+		c.client.SetHeader("User-Agent", userAgent)
+	}
+}
+
+// Throttles the requests of the client by the given throttle, which can be shared with other clients of the
+// same instance. Otherwise, the requests are unlimited.
+func WithThrottle(throttle *util.Throttle) ClientOpt {
+	return func(c *Client) {
+		c.throttle = throttle
+	}
+}
@@ -0,0 +1,73 @@
+package alertmanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+	"gopkg.in/yaml.v3"
+)
+
+const configURL = "api/v1/alerts"
+
+// The configuration of an Alertmanager instance, consisting of the Alertmanager configuration file
+// and the notification template files it references, by file name.
+type Config struct {
+	TemplateFiles      map[string]string `yaml:"template_files"`
+	AlertmanagerConfig string            `yaml:"alertmanager_config"`
+}
+
+// Returns nil if no configuration has been uploaded to the Alertmanager instance.
+func (c *Client) GetConfig(ctx context.Context) (*Config, error) {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Get(configURL)
+
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err := util.HandleError(err, resp, "failed to get Alertmanager configuration"); err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	if err := yaml.Unmarshal(resp.Body(), config); err != nil {
+		return nil, fmt.Errorf("failed to parse Alertmanager configuration: %v", err)
+	}
+
+	return config, nil
+}
+
+func (c *Client) SetConfig(ctx context.Context, config *Config) error {
+	body, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.R().
+		SetHeader("Content-Type", "application/yaml").
+		SetBody(body).
+		SetContext(ctx).
+		Post(configURL)
+
+	if err := util.HandleError(err, resp, "failed to save Alertmanager configuration"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Deletes the configuration, which resets the Alertmanager instance to its default configuration.
+func (c *Client) DeleteConfig(ctx context.Context) error {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(configURL)
+
+	if err := util.HandleError(err, resp, "failed to delete Alertmanager configuration"); err != nil {
+		return err
+	}
+
+	return nil
+}
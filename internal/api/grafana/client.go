@@ -1,15 +1,23 @@
 package grafana
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
 )
 
 type Client struct {
 	client *resty.Client
+
+	// Limits of requests to the Grafana API, which are unlimited if not positive
+	requestsPerSecond     float64
+	maxConcurrentRequests int
+
+	throttle *util.Throttle
 }
 
 type ClientOpt func(*Client)
@@ -25,7 +33,9 @@ func NewClient(baseURL, apiKey string, opts ...ClientOpt) (*Client, error) {
 		SetDebug(len(os.Getenv("HTTP_DEBUG")) != 0).
 		SetAuthToken(apiKey).
 		SetHostURL(url).
-		SetTimeout(30 * time.Second)
+		SetTimeout(30 * time.Second).
+		SetRetryCount(6).
+		AddRetryCondition(util.IsRateLimited)
 
 	c := &Client{
 		client: resty,
@@ -35,6 +45,9 @@ func NewClient(baseURL, apiKey string, opts ...ClientOpt) (*Client, error) {
 		opt(c)
 	}
 
+	c.throttle = util.NewThrottle(fmt.Sprintf("Grafana API at `%s`", url), c.requestsPerSecond, c.maxConcurrentRequests)
+	c.throttle.Apply(c.client)
+
 	return c, nil
 }
 
@@ -43,3 +56,15 @@ func WithUserAgent(userAgent string) ClientOpt {
 		c.client.SetHeader("User-Agent", userAgent)
 	}
 }
+
+func WithRateLimit(requestsPerSecond float64, maxConcurrentRequests int) ClientOpt {
+	return func(c *Client) {
+		c.requestsPerSecond = requestsPerSecond
+		c.maxConcurrentRequests = maxConcurrentRequests
+	}
+}
+
+// Logs how much the requests of the client have been throttled so far.
+func (c *Client) LogThrottleStats() {
+	c.throttle.LogStats()
+}
@@ -0,0 +1,122 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+// Grafana returns this instead of the values of secure settings.
+const RedactedSetting = "[REDACTED]"
+
+// A contact point in the Grafana UI groups all integrations (e.g. email or Slack) with the same
+// name, while the API treats each integration as a separate contact point.
+type ContactPoint struct {
+	UID                   string                 `json:"uid,omitempty"`
+	Name                  string                 `json:"name"`
+	Type                  string                 `json:"type"`
+	Settings              map[string]interface{} `json:"settings"`
+	DisableResolveMessage bool                   `json:"disableResolveMessage"`
+}
+
+type ListContactPointsOutput struct {
+	Items []*ContactPoint
+}
+
+func (c *Client) CreateContactPoint(ctx context.Context, r *ContactPoint) (*ContactPoint, error) {
+	url := "api/v1/provisioning/contact-points"
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&ContactPoint{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to create Grafana contact point"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ContactPoint), nil
+}
+
+// Lists all integrations of the contact point with the given name, or of all contact points if the
+// name is empty.
+func (c *Client) ListContactPoints(ctx context.Context, name string) (*ListContactPointsOutput, error) {
+	var contactPoints []*ContactPoint
+	url := "api/v1/provisioning/contact-points"
+
+	req := c.client.R().
+		SetResult(&contactPoints).
+		SetContext(ctx)
+
+	if name != "" {
+		req.SetQueryParam("name", name)
+	}
+
+	resp, err := req.Get(url)
+	if err := util.HandleError(err, resp, "failed to list Grafana contact points"); err != nil {
+		return nil, err
+	}
+
+	return &ListContactPointsOutput{
+		Items: contactPoints,
+	}, nil
+}
+
+func (c *Client) UpdateContactPoint(ctx context.Context, r *ContactPoint) error {
+	url := fmt.Sprintf("api/v1/provisioning/contact-points/%s", r.UID)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetContext(ctx).
+		Put(url)
+
+	if err := util.HandleError(err, resp, "failed to update Grafana contact point"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteContactPoint(ctx context.Context, uid string) error {
+	url := fmt.Sprintf("api/v1/provisioning/contact-points/%s", uid)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana contact point"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l *ListContactPointsOutput) FindByUID(uid string) *ContactPoint {
+	for _, cp := range l.Items {
+		if cp.UID == uid {
+			return cp
+		}
+	}
+
+	return nil
+}
+
+func (l *ListContactPointsOutput) DeleteByUID(uid string) {
+	for i, cp := range l.Items {
+		if cp.UID == uid {
+			l.Items = append(l.Items[:i], l.Items[i+1:]...)
+			return
+		}
+	}
+}
+
+// Returns the integrations of the contact point with the given name.
+func (l *ListContactPointsOutput) FilterByName(name string) []*ContactPoint {
+	result := make([]*ContactPoint, 0)
+	for _, cp := range l.Items {
+		if cp.Name == name {
+			result = append(result, cp)
+		}
+	}
+
+	return result
+}
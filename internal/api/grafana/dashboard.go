@@ -0,0 +1,90 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+type SaveDashboardInput struct {
+	Dashboard map[string]interface{} `json:"dashboard"`
+	FolderUID string                 `json:"folderUid,omitempty"`
+	Overwrite bool                   `json:"overwrite"`
+	Message   string                 `json:"message,omitempty"`
+}
+
+type SaveDashboardOutput struct {
+	ID      int
+	UID     string
+	URL     string
+	Status  string
+	Version int
+	Slug    string
+}
+
+type Dashboard struct {
+	Dashboard map[string]interface{}
+	Meta      *DashboardMeta
+}
+
+type DashboardMeta struct {
+	Slug        string
+	URL         string
+	Version     int
+	FolderID    int
+	FolderUID   string
+	FolderTitle string
+	Created     string
+	Updated     string
+}
+
+// Creates or updates a dashboard. Unless `Overwrite` is set, Grafana refuses to save the dashboard if
+// its `version` doesn't match the current version, so changes made in the meantime aren't lost.
+func (c *Client) SaveDashboard(ctx context.Context, r *SaveDashboardInput) (*SaveDashboardOutput, error) {
+	url := "api/dashboards/db"
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&SaveDashboardOutput{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to save Grafana dashboard"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*SaveDashboardOutput), nil
+}
+
+// Returns nil if the dashboard doesn't exist.
+func (c *Client) GetDashboard(ctx context.Context, uid string) (*Dashboard, error) {
+	url := fmt.Sprintf("api/dashboards/uid/%s", uid)
+	resp, err := c.client.R().
+		SetResult(&Dashboard{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err := util.HandleError(err, resp, "failed to read Grafana dashboard"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*Dashboard), nil
+}
+
+func (c *Client) DeleteDashboard(ctx context.Context, uid string) error {
+	url := fmt.Sprintf("api/dashboards/uid/%s", uid)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana dashboard"); err != nil {
+		return err
+	}
+
+	return nil
+}
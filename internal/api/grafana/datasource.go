@@ -0,0 +1,121 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+type CreateDatasourceInput struct {
+	UID            string                 `json:"uid,omitempty"`
+	Name           string                 `json:"name"`
+	Type           string                 `json:"type"`
+	URL            string                 `json:"url"`
+	Access         string                 `json:"access"`
+	IsDefault      bool                   `json:"isDefault"`
+	BasicAuth      bool                   `json:"basicAuth"`
+	BasicAuthUser  string                 `json:"basicAuthUser"`
+	JSONData       map[string]interface{} `json:"jsonData,omitempty"`
+	SecureJSONData map[string]string      `json:"secureJsonData,omitempty"`
+}
+
+// Secure JSON data not given is left unchanged.
+type UpdateDatasourceInput struct {
+	Name           string                 `json:"name"`
+	Type           string                 `json:"type"`
+	URL            string                 `json:"url"`
+	Access         string                 `json:"access"`
+	IsDefault      bool                   `json:"isDefault"`
+	BasicAuth      bool                   `json:"basicAuth"`
+	BasicAuthUser  string                 `json:"basicAuthUser"`
+	JSONData       map[string]interface{} `json:"jsonData,omitempty"`
+	SecureJSONData map[string]string      `json:"secureJsonData,omitempty"`
+	UID            string                 `json:"uid"`
+}
+
+type DatasourceOutput struct {
+	ID         int
+	Name       string
+	Message    string
+	Datasource *Datasource
+}
+
+// Secure JSON data is never returned by Grafana, only which fields of it are set.
+type Datasource struct {
+	ID               int                    `json:"id"`
+	UID              string                 `json:"uid"`
+	OrgID            int                    `json:"orgId"`
+	Name             string                 `json:"name"`
+	Type             string                 `json:"type"`
+	URL              string                 `json:"url"`
+	Access           string                 `json:"access"`
+	IsDefault        bool                   `json:"isDefault"`
+	BasicAuth        bool                   `json:"basicAuth"`
+	BasicAuthUser    string                 `json:"basicAuthUser"`
+	JSONData         map[string]interface{} `json:"jsonData"`
+	SecureJSONFields map[string]bool        `json:"secureJsonFields"`
+}
+
+func (c *Client) CreateDatasource(ctx context.Context, r *CreateDatasourceInput) (*Datasource, error) {
+	url := "api/datasources"
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&DatasourceOutput{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to create Grafana data source"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*DatasourceOutput).Datasource, nil
+}
+
+// Returns nil if the data source doesn't exist.
+func (c *Client) GetDatasource(ctx context.Context, uid string) (*Datasource, error) {
+	url := fmt.Sprintf("api/datasources/uid/%s", uid)
+	resp, err := c.client.R().
+		SetResult(&Datasource{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err := util.HandleError(err, resp, "failed to read Grafana data source"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*Datasource), nil
+}
+
+func (c *Client) UpdateDatasource(ctx context.Context, r *UpdateDatasourceInput) (*Datasource, error) {
+	url := fmt.Sprintf("api/datasources/uid/%s", r.UID)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&DatasourceOutput{}).
+		SetContext(ctx).
+		Put(url)
+
+	if err := util.HandleError(err, resp, "failed to update Grafana data source"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*DatasourceOutput).Datasource, nil
+}
+
+func (c *Client) DeleteDatasource(ctx context.Context, uid string) error {
+	url := fmt.Sprintf("api/datasources/uid/%s", uid)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana data source"); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+const (
+	FolderPermissionView  = 1
+	FolderPermissionEdit  = 2
+	FolderPermissionAdmin = 4
+)
+
+type CreateFolderInput struct {
+	UID   string `json:"uid,omitempty"`
+	Title string `json:"title"`
+}
+
+type UpdateFolderInput struct {
+	Title     string `json:"title"`
+	Overwrite bool   `json:"overwrite"`
+	UID       string `json:"-"`
+}
+
+type Folder struct {
+	ID      int
+	UID     string
+	Title   string
+	URL     string
+	Version int
+}
+
+type UpdateFolderPermissionsInput struct {
+	Items     []*FolderPermission `json:"items"`
+	FolderUID string              `json:"-"`
+}
+
+type ListFolderPermissionsOutput struct {
+	Items []*FolderPermission
+}
+
+// A folder permission is granted to either a role, a team or a user.
+type FolderPermission struct {
+	Role       string `json:"role,omitempty"`
+	TeamID     int    `json:"teamId,omitempty"`
+	UserID     int    `json:"userId,omitempty"`
+	Permission int    `json:"permission"`
+}
+
+func (c *Client) CreateFolder(ctx context.Context, r *CreateFolderInput) (*Folder, error) {
+	url := "api/folders"
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&Folder{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to create Grafana folder"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*Folder), nil
+}
+
+// Returns nil if the folder doesn't exist.
+func (c *Client) GetFolder(ctx context.Context, uid string) (*Folder, error) {
+	url := fmt.Sprintf("api/folders/%s", uid)
+	resp, err := c.client.R().
+		SetResult(&Folder{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err := util.HandleError(err, resp, "failed to read Grafana folder"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*Folder), nil
+}
+
+func (c *Client) UpdateFolder(ctx context.Context, r *UpdateFolderInput) (*Folder, error) {
+	url := fmt.Sprintf("api/folders/%s", r.UID)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&Folder{}).
+		SetContext(ctx).
+		Put(url)
+
+	if err := util.HandleError(err, resp, "failed to update Grafana folder"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*Folder), nil
+}
+
+// Deleting a folder deletes all dashboards and alert rules stored in it as well.
+func (c *Client) DeleteFolder(ctx context.Context, uid string) error {
+	url := fmt.Sprintf("api/folders/%s", uid)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana folder"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) ListFolderPermissions(ctx context.Context, folderUID string) (*ListFolderPermissionsOutput, error) {
+	var permissions []*FolderPermission
+	url := fmt.Sprintf("api/folders/%s/permissions", folderUID)
+
+	resp, err := c.client.R().
+		SetResult(&permissions).
+		SetContext(ctx).
+		Get(url)
+
+	if err := util.HandleError(err, resp, "failed to list Grafana folder permissions"); err != nil {
+		return nil, err
+	}
+
+	return &ListFolderPermissionsOutput{
+		Items: permissions,
+	}, nil
+}
+
+// Replaces all permissions of the folder with the given ones.
+func (c *Client) UpdateFolderPermissions(ctx context.Context, r *UpdateFolderPermissionsInput) error {
+	url := fmt.Sprintf("api/folders/%s/permissions", r.FolderUID)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to update Grafana folder permissions"); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+// Notifications of policies referencing a mute timing are muted during any of its intervals.
+type MuteTiming struct {
+	Name          string          `json:"name"`
+	TimeIntervals []*TimeInterval `json:"time_intervals"`
+}
+
+// All of the set fields must match for a time to be in the interval. Days of month, months and
+// years are ranges like `1:5` or single values, negative days of month count from the end.
+type TimeInterval struct {
+	Times       []*TimeRange `json:"times,omitempty"`
+	Weekdays    []string     `json:"weekdays,omitempty"`
+	DaysOfMonth []string     `json:"days_of_month,omitempty"`
+	Months      []string     `json:"months,omitempty"`
+	Years       []string     `json:"years,omitempty"`
+	Location    string       `json:"location,omitempty"`
+}
+
+// Start and end time of day as `HH:MM`.
+type TimeRange struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+func (c *Client) CreateMuteTiming(ctx context.Context, r *MuteTiming) (*MuteTiming, error) {
+	url := "api/v1/provisioning/mute-timings"
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&MuteTiming{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to create Grafana mute timing"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*MuteTiming), nil
+}
+
+// Returns nil if the mute timing doesn't exist.
+func (c *Client) GetMuteTiming(ctx context.Context, name string) (*MuteTiming, error) {
+	resp, err := c.client.R().
+		SetResult(&MuteTiming{}).
+		SetContext(ctx).
+		Get(muteTimingURL(name))
+
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err := util.HandleError(err, resp, "failed to read Grafana mute timing"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*MuteTiming), nil
+}
+
+func (c *Client) UpdateMuteTiming(ctx context.Context, r *MuteTiming) (*MuteTiming, error) {
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&MuteTiming{}).
+		SetContext(ctx).
+		Put(muteTimingURL(r.Name))
+
+	if err := util.HandleError(err, resp, "failed to update Grafana mute timing"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*MuteTiming), nil
+}
+
+func (c *Client) DeleteMuteTiming(ctx context.Context, name string) error {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(muteTimingURL(name))
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana mute timing"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func muteTimingURL(name string) string {
+	return fmt.Sprintf("api/v1/provisioning/mute-timings/%s", url.PathEscape(name))
+}
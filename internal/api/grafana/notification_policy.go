@@ -0,0 +1,67 @@
+package grafana
+
+import (
+	"context"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+// The root of the notification policy tree. It routes all alerts not matched by any of its child
+// policies to its contact point, and has no matchers itself.
+type NotificationPolicy struct {
+	Receiver          string                `json:"receiver,omitempty"`
+	GroupBy           []string              `json:"group_by,omitempty"`
+	Continue          bool                  `json:"continue,omitempty"`
+	ObjectMatchers    []ObjectMatcher       `json:"object_matchers,omitempty"`
+	MuteTimeIntervals []string              `json:"mute_time_intervals,omitempty"`
+	GroupWait         string                `json:"group_wait,omitempty"`
+	GroupInterval     string                `json:"group_interval,omitempty"`
+	RepeatInterval    string                `json:"repeat_interval,omitempty"`
+	Routes            []*NotificationPolicy `json:"routes,omitempty"`
+}
+
+// Label name, operator (one of `=`, `!=`, `=~` and `!~`) and value.
+type ObjectMatcher [3]string
+
+func (c *Client) GetNotificationPolicy(ctx context.Context) (*NotificationPolicy, error) {
+	url := "api/v1/provisioning/policies"
+	resp, err := c.client.R().
+		SetResult(&NotificationPolicy{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err := util.HandleError(err, resp, "failed to read Grafana notification policy"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*NotificationPolicy), nil
+}
+
+func (c *Client) SetNotificationPolicy(ctx context.Context, r *NotificationPolicy) error {
+	url := "api/v1/provisioning/policies"
+	resp, err := c.client.R().
+		SetBody(r).
+		SetContext(ctx).
+		Put(url)
+
+	if err := util.HandleError(err, resp, "failed to save Grafana notification policy"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Resets the notification policy tree to the default, routing all alerts to the default contact
+// point.
+func (c *Client) ResetNotificationPolicy(ctx context.Context) error {
+	url := "api/v1/provisioning/policies"
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to reset Grafana notification policy"); err != nil {
+		return err
+	}
+
+	return nil
+}
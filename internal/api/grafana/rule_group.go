@@ -0,0 +1,112 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+const (
+	AlertRuleNoDataStateNoData   = "NoData"
+	AlertRuleNoDataStateAlerting = "Alerting"
+	AlertRuleNoDataStateOK       = "OK"
+
+	AlertRuleExecErrStateAlerting = "Alerting"
+	AlertRuleExecErrStateError    = "Error"
+	AlertRuleExecErrStateOK       = "OK"
+)
+
+// Rule groups are identified by the UID of their folder and their title.
+type RuleGroup struct {
+	Title     string       `json:"title"`
+	FolderUID string       `json:"folderUid"`
+	Interval  int          `json:"interval"`
+	Rules     []*AlertRule `json:"rules"`
+}
+
+type AlertRule struct {
+	ID           int               `json:"id,omitempty"`
+	UID          string            `json:"uid,omitempty"`
+	OrgID        int               `json:"orgID"`
+	FolderUID    string            `json:"folderUID"`
+	RuleGroup    string            `json:"ruleGroup"`
+	Title        string            `json:"title"`
+	Condition    string            `json:"condition"`
+	Data         []*AlertQuery     `json:"data"`
+	NoDataState  string            `json:"noDataState"`
+	ExecErrState string            `json:"execErrState"`
+	For          string            `json:"for"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	IsPaused     bool              `json:"isPaused"`
+}
+
+type AlertQuery struct {
+	RefID             string                 `json:"refId"`
+	QueryType         string                 `json:"queryType"`
+	RelativeTimeRange *RelativeTimeRange     `json:"relativeTimeRange"`
+	DatasourceUID     string                 `json:"datasourceUid"`
+	Model             map[string]interface{} `json:"model"`
+}
+
+// Relative to now, in seconds.
+type RelativeTimeRange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// Returns nil if the rule group doesn't exist.
+func (c *Client) GetRuleGroup(ctx context.Context, folderUID, title string) (*RuleGroup, error) {
+	url := ruleGroupURL(folderUID, title)
+	resp, err := c.client.R().
+		SetResult(&RuleGroup{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err := util.HandleError(err, resp, "failed to read Grafana alert rule group"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*RuleGroup), nil
+}
+
+// Creates or replaces the rule group. Rules are matched by their UID, rules of the group missing
+// from the request are deleted.
+func (c *Client) PutRuleGroup(ctx context.Context, r *RuleGroup) (*RuleGroup, error) {
+	url := ruleGroupURL(r.FolderUID, r.Title)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&RuleGroup{}).
+		SetContext(ctx).
+		Put(url)
+
+	if err := util.HandleError(err, resp, "failed to save Grafana alert rule group"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*RuleGroup), nil
+}
+
+func (c *Client) DeleteRuleGroup(ctx context.Context, folderUID, title string) error {
+	url := ruleGroupURL(folderUID, title)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana alert rule group"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ruleGroupURL(folderUID, title string) string {
+	return fmt.Sprintf("api/v1/provisioning/folder/%s/rule-groups/%s", url.PathEscape(folderUID), url.PathEscape(title))
+}
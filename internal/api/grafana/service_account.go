@@ -0,0 +1,277 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+	"github.com/relvacode/iso8601"
+)
+
+type CreateServiceAccountInput struct {
+	Name       string `json:"name"`
+	Role       string `json:"role"`
+	IsDisabled bool   `json:"isDisabled"`
+}
+
+type UpdateServiceAccountInput struct {
+	Name       string `json:"name"`
+	Role       string `json:"role"`
+	IsDisabled bool   `json:"isDisabled"`
+	ID         int    `json:"-"`
+}
+
+type ListServiceAccountsOutput struct {
+	Items []*ServiceAccount
+}
+
+type SearchServiceAccountsOutput struct {
+	TotalCount      int
+	ServiceAccounts []*ServiceAccount
+	Page            int
+	PerPage         int
+}
+
+type ServiceAccount struct {
+	ID         int
+	OrgID      int
+	Name       string
+	Login      string
+	Role       string
+	IsDisabled bool
+}
+
+type CreateServiceAccountTokenInput struct {
+	Name             string `json:"name"`
+	SecondsToLive    int    `json:"secondsToLive,omitempty"`
+	ServiceAccountID int    `json:"-"`
+}
+
+type ListServiceAccountTokensOutput struct {
+	Items []*ServiceAccountToken
+}
+
+type ServiceAccountToken struct {
+	ID         int
+	Name       string
+	Created    string
+	Expiration string
+
+	// Only returned when creating the token
+	Key string
+}
+
+// Service accounts replace API keys in Grafana, which are deprecated.
+//
+// See https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/ for more information.
+func (c *Client) CreateServiceAccount(ctx context.Context, r *CreateServiceAccountInput) (*ServiceAccount, error) {
+	url := "api/serviceaccounts"
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&ServiceAccount{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to create Grafana service account"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ServiceAccount), nil
+}
+
+// Returns nil if the service account doesn't exist.
+func (c *Client) GetServiceAccount(ctx context.Context, id int) (*ServiceAccount, error) {
+	url := fmt.Sprintf("api/serviceaccounts/%d", id)
+	resp, err := c.client.R().
+		SetResult(&ServiceAccount{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err := util.HandleError(err, resp, "failed to read Grafana service account"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ServiceAccount), nil
+}
+
+func (c *Client) UpdateServiceAccount(ctx context.Context, r *UpdateServiceAccountInput) (*ServiceAccount, error) {
+	url := fmt.Sprintf("api/serviceaccounts/%d", r.ID)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&ServiceAccount{}).
+		SetContext(ctx).
+		Patch(url)
+
+	if err := util.HandleError(err, resp, "failed to update Grafana service account"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ServiceAccount), nil
+}
+
+func (c *Client) DeleteServiceAccount(ctx context.Context, id int) error {
+	url := fmt.Sprintf("api/serviceaccounts/%d", id)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana service account"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Only returns the first 1000 service accounts matching the query, which is plenty for looking up
+// a single service account by name.
+func (c *Client) SearchServiceAccounts(ctx context.Context, query string) (*ListServiceAccountsOutput, error) {
+	url := "api/serviceaccounts/search"
+	resp, err := c.client.R().
+		SetResult(&SearchServiceAccountsOutput{}).
+		SetQueryParam("query", query).
+		SetQueryParam("perpage", "1000").
+		SetContext(ctx).
+		Get(url)
+
+	if err := util.HandleError(err, resp, "failed to search Grafana service accounts"); err != nil {
+		return nil, err
+	}
+
+	return &ListServiceAccountsOutput{
+		Items: resp.Result().(*SearchServiceAccountsOutput).ServiceAccounts,
+	}, nil
+}
+
+// Converts an API key into a service account with a single token. The token keeps the ID and the
+// value of the API key, so clients using the key keep working. The service account is named after
+// the API key, but Grafana doesn't return it, so it has to be looked up separately.
+func (c *Client) MigrateAPIKey(ctx context.Context, id int) error {
+	url := fmt.Sprintf("api/serviceaccounts/migrate/%d", id)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to migrate Grafana API key to service account"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) CreateServiceAccountToken(ctx context.Context, r *CreateServiceAccountTokenInput) (*ServiceAccountToken, error) {
+	url := fmt.Sprintf("api/serviceaccounts/%d/tokens", r.ServiceAccountID)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&ServiceAccountToken{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to create Grafana service account token"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ServiceAccountToken), nil
+}
+
+func (c *Client) ListServiceAccountTokens(ctx context.Context, serviceAccountID int) (*ListServiceAccountTokensOutput, error) {
+	var tokens []*ServiceAccountToken
+	url := fmt.Sprintf("api/serviceaccounts/%d/tokens", serviceAccountID)
+
+	resp, err := c.client.R().
+		SetResult(&tokens).
+		SetContext(ctx).
+		Get(url)
+
+	if err := util.HandleError(err, resp, "failed to list Grafana service account tokens"); err != nil {
+		return nil, err
+	}
+
+	return &ListServiceAccountTokensOutput{
+		Items: tokens,
+	}, nil
+}
+
+func (c *Client) DeleteServiceAccountToken(ctx context.Context, serviceAccountID, id int) error {
+	url := fmt.Sprintf("api/serviceaccounts/%d/tokens/%d", serviceAccountID, id)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana service account token"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *ServiceAccountToken) IsExpired() (bool, error) {
+	if t.Expiration == "" {
+		return false, nil
+	}
+
+	expires, err := iso8601.ParseString(t.Expiration)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	return now.After(expires), nil
+}
+
+func (l *ListServiceAccountsOutput) AddServiceAccount(sa *ServiceAccount) {
+	l.Items = append(l.Items, sa)
+}
+
+func (l *ListServiceAccountsOutput) FindByID(id int) *ServiceAccount {
+	for _, sa := range l.Items {
+		if sa.ID == id {
+			return sa
+		}
+	}
+
+	return nil
+}
+
+func (l *ListServiceAccountsOutput) DeleteByID(id int) {
+	newItems := make([]*ServiceAccount, 0)
+
+	for _, sa := range l.Items {
+		if sa.ID != id {
+			newItems = append(newItems, sa)
+		}
+	}
+
+	l.Items = newItems
+}
+
+func (l *ListServiceAccountTokensOutput) AddToken(t *ServiceAccountToken) {
+	l.Items = append(l.Items, t)
+}
+
+func (l *ListServiceAccountTokensOutput) FindByID(id int) *ServiceAccountToken {
+	for _, t := range l.Items {
+		if t.ID == id {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (l *ListServiceAccountTokensOutput) DeleteByID(id int) {
+	newItems := make([]*ServiceAccountToken, 0)
+
+	for _, t := range l.Items {
+		if t.ID != id {
+			newItems = append(newItems, t)
+		}
+	}
+
+	l.Items = newItems
+}
@@ -0,0 +1,144 @@
+package portal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+const (
+	AccessPolicyRealmOrg   = "org"
+	AccessPolicyRealmStack = "stack"
+)
+
+type CreateAccessPolicyInput struct {
+	Name        string               `json:"name"`
+	DisplayName string               `json:"displayName,omitempty"`
+	Scopes      []string             `json:"scopes"`
+	Realms      []*AccessPolicyRealm `json:"realms"`
+	Region      string               `json:"-"`
+}
+
+type UpdateAccessPolicyInput struct {
+	DisplayName string               `json:"displayName,omitempty"`
+	Scopes      []string             `json:"scopes"`
+	Realms      []*AccessPolicyRealm `json:"realms"`
+	ID          string               `json:"-"`
+	Region      string               `json:"-"`
+}
+
+type ListAccessPoliciesOutput struct {
+	Items []*AccessPolicy
+}
+
+type AccessPolicy struct {
+	ID          string
+	OrgID       string
+	Name        string
+	DisplayName string
+	Scopes      []string
+	Realms      []*AccessPolicyRealm
+	CreatedAt   string
+	UpdatedAt   string
+}
+
+type AccessPolicyRealm struct {
+	Type          string                     `json:"type"`
+	Identifier    string                     `json:"identifier"`
+	LabelPolicies []*AccessPolicyLabelPolicy `json:"labelPolicies"`
+}
+
+type AccessPolicyLabelPolicy struct {
+	Selector string `json:"selector"`
+}
+
+// Access policies are stored per region, which is why all of the functions below require one.
+//
+// See https://grafana.com/docs/grafana-cloud/reference/cloud-api/#access-policies for more information.
+func (c *Client) CreateAccessPolicy(ctx context.Context, r *CreateAccessPolicyInput) (*AccessPolicy, error) {
+	url := "v1/accesspolicies"
+	resp, err := c.client.R().
+		SetBody(r).
+		SetQueryParam("region", r.Region).
+		SetResult(&AccessPolicy{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to create Grafana Cloud access policy"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*AccessPolicy), nil
+}
+
+func (c *Client) ListAccessPolicies(ctx context.Context, region string) (*ListAccessPoliciesOutput, error) {
+	url := "v1/accesspolicies"
+	resp, err := c.client.R().
+		SetQueryParam("region", region).
+		SetResult(&ListAccessPoliciesOutput{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err := util.HandleError(err, resp, "failed to list Grafana Cloud access policies"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ListAccessPoliciesOutput), nil
+}
+
+func (c *Client) UpdateAccessPolicy(ctx context.Context, r *UpdateAccessPolicyInput) (*AccessPolicy, error) {
+	url := fmt.Sprintf("v1/accesspolicies/%s", r.ID)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetQueryParam("region", r.Region).
+		SetResult(&AccessPolicy{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to update Grafana Cloud access policy"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*AccessPolicy), nil
+}
+
+func (c *Client) DeleteAccessPolicy(ctx context.Context, region, id string) error {
+	url := fmt.Sprintf("v1/accesspolicies/%s", id)
+	resp, err := c.client.R().
+		SetQueryParam("region", region).
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana Cloud access policy"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l *ListAccessPoliciesOutput) AddPolicy(p *AccessPolicy) {
+	l.Items = append(l.Items, p)
+}
+
+func (l *ListAccessPoliciesOutput) FindByID(id string) *AccessPolicy {
+	for _, p := range l.Items {
+		if p.ID == id {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func (l *ListAccessPoliciesOutput) DeleteByID(id string) {
+	newItems := make([]*AccessPolicy, 0)
+
+	for _, p := range l.Items {
+		if p.ID != id {
+			newItems = append(newItems, p)
+		}
+	}
+
+	l.Items = newItems
+}
@@ -0,0 +1,136 @@
+package portal
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+	"github.com/relvacode/iso8601"
+)
+
+type CreateAccessPolicyTokenInput struct {
+	AccessPolicyID string `json:"accessPolicyId"`
+	Name           string `json:"name"`
+	DisplayName    string `json:"displayName,omitempty"`
+	ExpiresAt      string `json:"expiresAt,omitempty"`
+	Region         string `json:"-"`
+}
+
+type ListAccessPolicyTokensOutput struct {
+	Items []*AccessPolicyToken
+}
+
+type AccessPolicyToken struct {
+	ID             string
+	AccessPolicyID string
+	Name           string
+	DisplayName    string
+	ExpiresAt      string
+	FirstUsedAt    string
+	CreatedAt      string
+
+	// Only returned when creating the token
+	Token string
+}
+
+// Tokens are always created for an existing access policy and share its scopes and realms.
+//
+// See https://grafana.com/docs/grafana-cloud/reference/cloud-api/#tokens for more information.
+func (c *Client) CreateAccessPolicyToken(ctx context.Context, r *CreateAccessPolicyTokenInput) (*AccessPolicyToken, error) {
+	url := "v1/tokens"
+	resp, err := c.client.R().
+		SetBody(r).
+		SetQueryParam("region", r.Region).
+		SetResult(&AccessPolicyToken{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to create Grafana Cloud access policy token"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*AccessPolicyToken), nil
+}
+
+func (c *Client) ListAccessPolicyTokens(ctx context.Context, region, accessPolicyID string) (*ListAccessPolicyTokensOutput, error) {
+	url := "v1/tokens"
+	resp, err := c.client.R().
+		SetQueryParam("region", region).
+		SetQueryParam("accessPolicyId", accessPolicyID).
+		SetResult(&ListAccessPolicyTokensOutput{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err := util.HandleError(err, resp, "failed to list Grafana Cloud access policy tokens"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ListAccessPolicyTokensOutput), nil
+}
+
+func (c *Client) DeleteAccessPolicyToken(ctx context.Context, region, id string) error {
+	url := fmt.Sprintf("v1/tokens/%s", id)
+	resp, err := c.client.R().
+		SetQueryParam("region", region).
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete Grafana Cloud access policy token"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (t *AccessPolicyToken) IsExpired() (bool, error) {
+	if t.ExpiresAt == "" {
+		return false, nil
+	}
+
+	expires, err := iso8601.ParseString(t.ExpiresAt)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	return now.After(expires), nil
+}
+
+func (l *ListAccessPolicyTokensOutput) AddToken(t *AccessPolicyToken) {
+	l.Items = append(l.Items, t)
+}
+
+func (l *ListAccessPolicyTokensOutput) FindByID(id string) *AccessPolicyToken {
+	for _, t := range l.Items {
+		if t.ID == id {
+			return t
+		}
+	}
+
+	return nil
+}
+
+func (l *ListAccessPolicyTokensOutput) DeleteByID(id string) {
+	newItems := make([]*AccessPolicyToken, 0)
+
+	for _, t := range l.Items {
+		if t.ID != id {
+			newItems = append(newItems, t)
+		}
+	}
+
+	l.Items = newItems
+}
+
+func (l *ListAccessPolicyTokensOutput) DeleteByAccessPolicyID(accessPolicyID string) {
+	newItems := make([]*AccessPolicyToken, 0)
+
+	for _, t := range l.Items {
+		if t.AccessPolicyID != accessPolicyID {
+			newItems = append(newItems, t)
+		}
+	}
+
+	l.Items = newItems
+}
@@ -0,0 +1,39 @@
+package portal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/alertmanager"
+)
+
+// Like the rulers, the Alertmanager instance of a stack accepts the Grafana Cloud API key with its
+// user ID via basic auth.
+func (c *Client) GetAlertmanagerClient(ctx context.Context, orgName, stackName string) (*alertmanager.Client, error) {
+	stack, err := c.GetStack(ctx, orgName, stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	if stack == nil {
+		return nil, fmt.Errorf("failed to find stack by name %s", stackName)
+	}
+
+	datasource, err := c.FindAlertmanagerDatasource(ctx, stack.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if datasource == nil {
+		return nil, fmt.Errorf("failed to find Alertmanager instance of stack %s", stackName)
+	}
+
+	return alertmanager.NewClient(
+		datasource.URL,
+		strconv.Itoa(stack.AmInstanceID),
+		c.client.Token,
+		alertmanager.WithUserAgent(c.client.Header.Get("User-Agent")),
+		alertmanager.WithThrottle(c.instanceThrottle("Alertmanager API", datasource.URL)),
+	)
+}
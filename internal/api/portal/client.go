@@ -1,19 +1,25 @@
 package portal
 
 import (
+	"fmt"
 	"log"
-	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
 )
 
 const (
 	grafanaStarting       = "Your instance is starting"
 	TempKeyDefaultExpires = 60
 	TempKeyDefaultPrefix  = "terraform-provider-grafanacloud-tmp"
+
+	// Requests per second to the Grafana Cloud API and the Grafana, ruler and Alertmanager APIs of each stack
+	DefaultRequestsPerSecond = 10
 )
 
 type Client struct {
@@ -28,6 +34,28 @@ type Client struct {
 	// Temporarily created Grafana API admin tokens have a prefix so you can identify them
 	// easily, which defaults to the value of constant constant `TempKeyPrefix`.
 	TempKeyPrefix string
+
+	// Tokens to authenticate with the Grafana API of stacks by stack slug, which are used instead of
+	// temporary keys.
+	stackTokens map[string]string
+
+	grafanaClients *grafanaClientCache
+
+	// Whether to sweep the temporary keys left behind on a stack once its Grafana API is first used
+	sweepTempKeysOnUse bool
+
+	// Limits of requests to the Grafana Cloud API, which apply to the Grafana, ruler and Alertmanager APIs
+	// of each stack as well.
+	// Both are unlimited if not positive.
+	RequestsPerSecond     float64
+	MaxConcurrentRequests int
+
+	throttle *util.Throttle
+
+	// Throttles of the ruler and Alertmanager APIs of the stacks by their URL, which are shared by the
+	// clients created for every request
+	instanceThrottlesMu sync.Mutex
+	instanceThrottles   map[string]*util.Throttle
 }
 
 type ClientOpt func(*Client)
@@ -44,7 +72,6 @@ func NewClient(baseURL, apiKey string, opts ...ClientOpt) (*Client, error) {
 		SetAuthToken(apiKey).
 		SetHostURL(url).
 		SetTimeout(10 * time.Second).
-		SetRetryWaitTime(10 * time.Second).
 		SetRetryCount(6).
 		AddRetryCondition(canRetry).
 		AddRetryHook(logRetry)
@@ -52,12 +79,22 @@ func NewClient(baseURL, apiKey string, opts ...ClientOpt) (*Client, error) {
 	c := &Client{
 		client:         resty,
 		TempKeyExpires: TempKeyDefaultExpires * time.Second,
+		stackTokens:    make(map[string]string),
+		grafanaClients: &grafanaClientCache{
+			stacks: make(map[string]*sync.Mutex),
+			tokens: make(map[string]*grafana.Client),
+			keys:   make(map[string]*tempKey),
+		},
+		instanceThrottles: make(map[string]*util.Throttle),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
+	c.throttle = util.NewThrottle("Grafana Cloud API", c.RequestsPerSecond, c.MaxConcurrentRequests)
+	c.throttle.Apply(c.client)
+
 	return c, nil
 }
 
@@ -79,11 +116,50 @@ func WithTempKeyPrefix(prefix string) ClientOpt {
 	}
 }
 
+// Limits the requests per second and the requests in flight, to the Grafana Cloud API as well as to the
+// Grafana, ruler and Alertmanager APIs of each stack.
+func WithRateLimit(requestsPerSecond float64, maxConcurrentRequests int) ClientOpt {
+	return func(c *Client) {
+		c.RequestsPerSecond = requestsPerSecond
+		c.MaxConcurrentRequests = maxConcurrentRequests
+	}
+}
+
+// Sweeps the temporary keys left behind on a stack once the client first uses its Grafana API, with the
+// credentials it uses anyway. Stacks whose Grafana API isn't used aren't swept.
+func WithTempKeySweeping() ClientOpt {
+	return func(c *Client) {
+		c.sweepTempKeysOnUse = true
+	}
+}
+
+// Authenticates with the Grafana API of the stack by the token, e.g. of a service account or a stack-scoped
+// access policy, instead of temporary keys.
+func WithStackToken(stackSlug, token string) ClientOpt {
+	return func(c *Client) {
+		c.stackTokens[stackSlug] = token
+	}
+}
+
+// Returns the throttle shared by all clients of the API at the URL, which is limited like the Grafana Cloud API.
+func (c *Client) instanceThrottle(name, url string) *util.Throttle {
+	c.instanceThrottlesMu.Lock()
+	defer c.instanceThrottlesMu.Unlock()
+
+	throttle, ok := c.instanceThrottles[url]
+	if !ok {
+		throttle = util.NewThrottle(fmt.Sprintf("%s at `%s`", name, url), c.RequestsPerSecond, c.MaxConcurrentRequests)
+		c.instanceThrottles[url] = throttle
+	}
+
+	return throttle
+}
+
 // We retry for two reasons:
 // 1. Grafana Cloud APIs might apply rate limiting to API requests
 // 2. Newly created Grafana Cloud Stacks don't accept requests to create Grafana API keys immediately
 func canRetry(r *resty.Response, err error) bool {
-	return r.StatusCode() == http.StatusTooManyRequests ||
+	return util.IsRateLimited(r, err) ||
 		strings.Contains(r.String(), grafanaStarting)
 }
 
@@ -12,6 +12,7 @@ type ListDatasourcesOutput struct {
 }
 type Datasource struct {
 	ID            int
+	UID           string
 	InstanceID    int
 	InstanceSlug  string
 	Name          string
@@ -38,3 +39,35 @@ func (c *Client) ListDatasources(ctx context.Context, stack string) (*ListDataso
 func (ds *Datasource) IsAlertmanager() bool {
 	return ds.Type == "grafana-alertmanager-datasource"
 }
+
+// Returns the data sources matching the given type and name. Empty values match all data sources.
+func (l *ListDatasourcesOutput) Filter(dsType, name string) *ListDatasourcesOutput {
+	result := &ListDatasourcesOutput{
+		Items: make([]*Datasource, 0),
+	}
+
+	for _, ds := range l.Items {
+		if (dsType == "" || ds.Type == dsType) && (name == "" || ds.Name == name) {
+			result.Items = append(result.Items, ds)
+		}
+	}
+
+	return result
+}
+
+// The Grafana Cloud API doesn't provide the URL of the Alertmanager instance of a stack, but the
+// Grafana instance of the stack comes with a data source for it. Returns nil if there's none.
+func (c *Client) FindAlertmanagerDatasource(ctx context.Context, stack string) (*Datasource, error) {
+	ds, err := c.ListDatasources(ctx, stack)
+	if err != nil {
+		return nil, fmt.Errorf("error while locating Alertmanager instance for stack %s: %v", stack, err)
+	}
+
+	for _, datasource := range ds.Items {
+		if datasource.IsAlertmanager() {
+			return datasource, nil
+		}
+	}
+
+	return nil, nil
+}
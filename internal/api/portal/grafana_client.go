@@ -4,22 +4,103 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
 )
 
-// The Grafana Cloud API is disconnected from the Grafana API on the stacks unfortunately. That's why we can't use
-// the Grafana Cloud API key to fully manage API keys on the Grafana API. The only thing we can do is to create
-// a temporary Admin key, and create a Grafana API client with that.
-func (c *Client) GetAuthedGrafanaClient(ctx context.Context, orgName, stackName string) (*grafana.Client, func() error, error) {
-	stack, err := c.GetStack(ctx, orgName, stackName)
+// A temporary admin API key on the Grafana instance of a stack, together with a client using it.
+type tempKey struct {
+	id      int
+	name    string
+	stack   string
+	client  *grafana.Client
+	expires time.Time
+}
+
+// Grafana clients are cached by stack for the lifetime of the client, so that not every request to
+// the Grafana API needs to create and delete a temporary key of its own.
+type grafanaClientCache struct {
+	mu sync.Mutex
+
+	// Clients are only created while holding the lock of their stack, so concurrent requests to a stack share
+	// a single new client, while requests to other stacks aren't blocked by its network calls
+	stacks map[string]*sync.Mutex
+
+	// Clients of stacks with a token configured by `WithStackToken`
+	tokens map[string]*grafana.Client
+
+	// Temporary keys of all other stacks
+	keys map[string]*tempKey
+
+	// Keys which have been replaced, but might still be used by requests in flight
+	retired []*tempKey
+}
+
+// Returns a client for the Grafana API of the stack, which uses the token configured for the stack by
+// `WithStackToken` if there's one.
+//
+// Otherwise, this falls back to a temporary admin key. The Grafana Cloud API is disconnected from the Grafana
+// API on the stacks unfortunately. That's why we can't use the Grafana Cloud API key to fully manage API keys on
+// the Grafana API. The only thing we can do is to create a temporary Admin key, and create a Grafana API client
+// with that. The client is cached per stack and replaced by one with a new key once half of the lifetime of its
+// key has passed, so requests in flight don't run into expired keys. All keys are deleted by `Close`.
+func (c *Client) GetAuthedGrafanaClient(ctx context.Context, orgName, stackName string) (*grafana.Client, error) {
+	return c.getAuthedGrafanaClient(ctx, stackName, func() (*Stack, error) {
+		return c.GetStack(ctx, orgName, stackName)
+	})
+}
+
+// The stack is only looked up if there's no cached client for it yet.
+func (c *Client) getAuthedGrafanaClient(ctx context.Context, stackName string, getStack func() (*Stack, error)) (*grafana.Client, error) {
+	lock := c.grafanaClients.stackLock(stackName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	c.grafanaClients.mu.Lock()
+	tokenClient, hasTokenClient := c.grafanaClients.tokens[stackName]
+	cached, hasCached := c.grafanaClients.keys[stackName]
+	c.grafanaClients.mu.Unlock()
+
+	if hasTokenClient {
+		return tokenClient, nil
+	}
+
+	if token, ok := c.stackTokens[stackName]; ok {
+		stack, err := getStack()
+		if err != nil {
+			return nil, err
+		}
+
+		if stack == nil {
+			return nil, fmt.Errorf("failed to find stack by name %s", stackName)
+		}
+
+		client, err := c.newGrafanaClient(stack.URL, token)
+		if err != nil {
+			return nil, err
+		}
+
+		c.grafanaClients.mu.Lock()
+		c.grafanaClients.tokens[stackName] = client
+		c.grafanaClients.mu.Unlock()
+
+		c.sweepOnFirstUse(ctx, stackName, client)
+		return client, nil
+	}
+
+	if hasCached && (c.TempKeyExpires <= 0 || time.Until(cached.expires) > c.TempKeyExpires/2) {
+		return cached.client, nil
+	}
+
+	stack, err := getStack()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	if stack == nil {
-		return nil, nil, fmt.Errorf("failed to find stack by name %s", stackName)
+		return nil, fmt.Errorf("failed to find stack by name %s", stackName)
 	}
 
 	name := fmt.Sprintf("%s-%d", c.TempKeyPrefix, time.Now().UnixNano())
@@ -30,28 +111,177 @@ func (c *Client) GetAuthedGrafanaClient(ctx context.Context, orgName, stackName
 		Stack:         stackName,
 	}
 
+	expires := time.Now().Add(c.TempKeyExpires)
 	apiKey, err := c.CreateGrafanaAPIKey(ctx, req)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	log.Printf("[DEBUG] created a temporary admin API key `%s` on Grafana stack `%s`", apiKey.Name, stack.Slug)
 
-	client, err := grafana.NewClient(stack.URL, apiKey.Key, grafana.WithUserAgent(c.client.Header.Get("User-Agent")))
+	client, err := c.newGrafanaClient(stack.URL, apiKey.Key)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	cleanup := func() error {
-		err = client.DeleteAPIKey(ctx, apiKey.ID)
+	c.grafanaClients.mu.Lock()
+	if hasCached {
+		c.grafanaClients.retired = append(c.grafanaClients.retired, cached)
+	}
+
+	c.grafanaClients.keys[stackName] = &tempKey{
+		id:      apiKey.ID,
+		name:    apiKey.Name,
+		stack:   stack.Slug,
+		client:  client,
+		expires: expires,
+	}
+	c.grafanaClients.mu.Unlock()
+
+	if !hasCached {
+		c.sweepOnFirstUse(ctx, stack.Slug, client)
+	}
+
+	return client, nil
+}
+
+// Sweeping is best-effort, so it never fails the request which needs the client.
+func (c *Client) sweepOnFirstUse(ctx context.Context, stackSlug string, client *grafana.Client) {
+	if !c.sweepTempKeysOnUse || c.TempKeyPrefix == "" {
+		return
+	}
+
+	if _, err := c.sweepTempKeysWith(ctx, stackSlug, client, false); err != nil {
+		log.Printf("[WARN] failed sweeping temporary admin API keys on Grafana stack `%s`: %v", stackSlug, err)
+	}
+}
+
+func (g *grafanaClientCache) stackLock(stackName string) *sync.Mutex {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	lock, ok := g.stacks[stackName]
+	if !ok {
+		lock = &sync.Mutex{}
+		g.stacks[stackName] = lock
+	}
+
+	return lock
+}
+
+func (c *Client) newGrafanaClient(url, token string) (*grafana.Client, error) {
+	return grafana.NewClient(
+		url,
+		token,
+		grafana.WithUserAgent(c.client.Header.Get("User-Agent")),
+		grafana.WithRateLimit(c.RequestsPerSecond, c.MaxConcurrentRequests),
+	)
+}
+
+// Deletes all temporary admin API keys created by the client which haven't expired yet. The client can still be
+// used afterwards, creating new keys as needed.
+func (c *Client) Close(ctx context.Context) error {
+	c.throttle.LogStats()
+
+	c.instanceThrottlesMu.Lock()
+	for _, throttle := range c.instanceThrottles {
+		throttle.LogStats()
+	}
+	c.instanceThrottlesMu.Unlock()
+
+	c.grafanaClients.mu.Lock()
+	for _, client := range c.grafanaClients.tokens {
+		client.LogThrottleStats()
+	}
+
+	keys := c.grafanaClients.retired
+	for _, key := range c.grafanaClients.keys {
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		key.client.LogThrottleStats()
+	}
+
+	c.grafanaClients.keys = make(map[string]*tempKey)
+	c.grafanaClients.retired = nil
+	c.grafanaClients.mu.Unlock()
+
+	// Keys are deleted in parallel, as Terraform only waits shortly for the provider to exit
+	var wg sync.WaitGroup
+	errs := make(chan error, len(keys))
+	for _, key := range keys {
+		if c.TempKeyExpires > 0 && time.Now().After(key.expires) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(key *tempKey) {
+			defer wg.Done()
+			errs <- key.delete(ctx)
+		}(key)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	failed := 0
+	for err := range errs {
 		if err != nil {
-			log.Printf("[ERROR] failed deleting temporary admin API key `%s` on Grafana stack `%s`", apiKey.Name, stack.Slug)
-			return err
+			failed++
 		}
+	}
 
-		log.Printf("[DEBUG] deleted temporary admin API key `%s` on Grafana stack `%s`", apiKey.Name, stack.Slug)
-		return nil
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d temporary admin API keys, which expire after %s", failed, c.TempKeyExpires)
+	}
+
+	return nil
+}
+
+// Whether the key is one of the temporary keys created by the client, which might still be in use.
+func (c *Client) ownsTempKey(stackSlug string, id int) bool {
+	c.grafanaClients.mu.Lock()
+	defer c.grafanaClients.mu.Unlock()
+
+	if key, ok := c.grafanaClients.keys[stackSlug]; ok && key.id == id {
+		return true
+	}
+
+	for _, key := range c.grafanaClients.retired {
+		if key.stack == stackSlug && key.id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Drops the cached clients and keys of a stack without deleting the keys, e.g. because the stack has been deleted.
+func (c *Client) forgetGrafanaClients(stackSlug string) {
+	c.grafanaClients.mu.Lock()
+	defer c.grafanaClients.mu.Unlock()
+
+	delete(c.grafanaClients.tokens, stackSlug)
+	delete(c.grafanaClients.keys, stackSlug)
+
+	retired := make([]*tempKey, 0, len(c.grafanaClients.retired))
+	for _, key := range c.grafanaClients.retired {
+		if key.stack != stackSlug {
+			retired = append(retired, key)
+		}
+	}
+
+	c.grafanaClients.retired = retired
+}
+
+func (k *tempKey) delete(ctx context.Context) error {
+	err := k.client.DeleteAPIKey(ctx, k.id)
+	if err != nil {
+		log.Printf("[ERROR] failed deleting temporary admin API key `%s` on Grafana stack `%s`", k.name, k.stack)
+		return err
 	}
 
-	return client, cleanup, nil
+	log.Printf("[DEBUG] deleted temporary admin API key `%s` on Grafana stack `%s`", k.name, k.stack)
+	return nil
 }
@@ -0,0 +1,57 @@
+package portal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+const (
+	HostedMetrics = "hosted-metrics"
+	HostedLogs    = "hosted-logs"
+	HostedTraces  = "hosted-traces"
+)
+
+// Hosted instances are the Prometheus, Loki and Tempo backends of a stack. Their ID doubles as the
+// user name for pushing data to them, along with a Grafana Cloud API key as the password.
+type HostedInstance struct {
+	ID          int
+	OrgID       int
+	OrgSlug     string
+	Name        string
+	Type        string
+	URL         string
+	Description string
+	Status      string
+	ClusterID   int
+	ClusterSlug string
+	ClusterName string
+	CreatedAt   string
+}
+
+func (c *Client) GetHostedMetricsInstance(ctx context.Context, id int) (*HostedInstance, error) {
+	return c.getHostedInstance(ctx, HostedMetrics, id)
+}
+
+func (c *Client) GetHostedLogsInstance(ctx context.Context, id int) (*HostedInstance, error) {
+	return c.getHostedInstance(ctx, HostedLogs, id)
+}
+
+func (c *Client) GetHostedTracesInstance(ctx context.Context, id int) (*HostedInstance, error) {
+	return c.getHostedInstance(ctx, HostedTraces, id)
+}
+
+func (c *Client) getHostedInstance(ctx context.Context, kind string, id int) (*HostedInstance, error) {
+	url := fmt.Sprintf("%s/%d", kind, id)
+	resp, err := c.client.R().
+		SetResult(&HostedInstance{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err := util.HandleError(err, resp, fmt.Sprintf("failed to read Grafana Cloud %s instance", kind)); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*HostedInstance), nil
+}
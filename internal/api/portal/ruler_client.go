@@ -0,0 +1,45 @@
+package portal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/ruler"
+)
+
+// The rulers of the hosted instances of a stack accept the Grafana Cloud API key with the user ID of
+// the instance via basic auth, so unlike the Grafana API no temporary key is required.
+func (c *Client) GetPrometheusRulerClient(ctx context.Context, orgName, stackName string) (*ruler.Client, error) {
+	return c.getRulerClient(ctx, orgName, stackName, func(stack *Stack) (string, int, string) {
+		return stack.HmInstancePromURL, stack.HmInstancePromID, ruler.PrometheusPathPrefix
+	})
+}
+
+func (c *Client) GetLokiRulerClient(ctx context.Context, orgName, stackName string) (*ruler.Client, error) {
+	return c.getRulerClient(ctx, orgName, stackName, func(stack *Stack) (string, int, string) {
+		return stack.HlInstanceURL, stack.HlInstanceID, ruler.LokiPathPrefix
+	})
+}
+
+// The instance function returns the URL, user ID and ruler path prefix of the hosted instance.
+func (c *Client) getRulerClient(ctx context.Context, orgName, stackName string, instance func(*Stack) (string, int, string)) (*ruler.Client, error) {
+	stack, err := c.GetStack(ctx, orgName, stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	if stack == nil {
+		return nil, fmt.Errorf("failed to find stack by name %s", stackName)
+	}
+
+	url, userID, pathPrefix := instance(stack)
+	return ruler.NewClient(
+		url,
+		pathPrefix,
+		strconv.Itoa(userID),
+		c.client.Token,
+		ruler.WithUserAgent(c.client.Header.Get("User-Agent")),
+		ruler.WithThrottle(c.instanceThrottle("ruler API", url)),
+	)
+}
@@ -7,10 +7,33 @@ import (
 	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
 )
 
+const (
+	StackStatusActive   = "active"
+	StackStatusStarting = "starting"
+	StackStatusCreating = "creating"
+	StackStatusFailed   = "failed"
+	StackStatusDeleting = "deleting"
+	StackStatusDeleted  = "deleted"
+)
+
 type CreateStackInput struct {
-	Name string `json:"name"`
-	Slug string `json:"slug"`
-	URL  string `json:"url"`
+	Name        string            `json:"name"`
+	Slug        string            `json:"slug"`
+	URL         string            `json:"url"`
+	Region      string            `json:"region,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+
+	// Slug of the organisation to create the stack in, which defaults to the organisation of the API key
+	Org string `json:"org,omitempty"`
+}
+
+type UpdateStackInput struct {
+	Name        string            `json:"name"`
+	URL         string            `json:"url,omitempty"`
+	Description string            `json:"description"`
+	Labels      map[string]string `json:"labels"`
+	Slug        string            `json:"-"`
 }
 
 type ListStacksOutput struct {
@@ -18,19 +41,34 @@ type ListStacksOutput struct {
 }
 
 type Stack struct {
-	ID                   int
-	OrgID                int
-	OrgSlug              string
-	OrgName              string
-	Name                 string
-	URL                  string
-	Status               string
-	Slug                 string
-	HmInstancePromID     int
-	HmInstancePromURL    string
-	HmInstancePromStatus string
-	AmInstanceID         int
-	AmInstanceURL        string
+	ID                       int
+	OrgID                    int
+	OrgSlug                  string
+	OrgName                  string
+	Name                     string
+	URL                      string
+	Status                   string
+	Slug                     string
+	Description              string
+	RegionSlug               string
+	Labels                   map[string]string
+	HmInstancePromID         int
+	HmInstancePromURL        string
+	HmInstancePromStatus     string
+	HmInstanceGraphiteID     int
+	HmInstanceGraphiteURL    string
+	HmInstanceGraphiteStatus string
+	HlInstanceID             int
+	HlInstanceURL            string
+	HlInstanceStatus         string
+	HtInstanceID             int
+	HtInstanceURL            string
+	HtInstanceStatus         string
+	HpInstanceID             int
+	HpInstanceURL            string
+	HpInstanceStatus         string
+	AmInstanceID             int
+	AmInstanceURL            string
 }
 
 func (c *Client) CreateStack(ctx context.Context, r *CreateStackInput) (*Stack, error) {
@@ -48,6 +86,21 @@ func (c *Client) CreateStack(ctx context.Context, r *CreateStackInput) (*Stack,
 	return resp.Result().(*Stack), nil
 }
 
+func (c *Client) UpdateStack(ctx context.Context, r *UpdateStackInput) (*Stack, error) {
+	url := fmt.Sprintf("instances/%s", r.Slug)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&Stack{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to update Grafana Cloud stack"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*Stack), nil
+}
+
 func (c *Client) ListStacks(ctx context.Context, org string) (*ListStacksOutput, error) {
 	url := fmt.Sprintf("orgs/%s/instances", org)
 	resp, err := c.client.R().
@@ -82,6 +135,9 @@ func (c *Client) DeleteStack(ctx context.Context, stackSlug string) error {
 		return err
 	}
 
+	// Any keys and tokens have been deleted together with the stack
+	c.forgetGrafanaClients(stackSlug)
+
 	return nil
 }
 
@@ -0,0 +1,120 @@
+package portal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+type InstallStackPluginInput struct {
+	Plugin  string `json:"plugin"`
+	Version string `json:"version,omitempty"`
+	Stack   string `json:"-"`
+}
+
+type UpdateStackPluginInput struct {
+	Version string `json:"version"`
+	Stack   string `json:"-"`
+	Plugin  string `json:"-"`
+}
+
+type ListStackPluginsOutput struct {
+	Items []*StackPlugin
+}
+
+type StackPlugin struct {
+	ID            int
+	InstanceID    int
+	InstanceSlug  string
+	PluginID      int
+	PluginSlug    string
+	PluginName    string
+	Version       string
+	LatestVersion string
+	CreatedAt     string
+	UpdatedAt     string
+}
+
+func (c *Client) InstallStackPlugin(ctx context.Context, r *InstallStackPluginInput) (*StackPlugin, error) {
+	url := fmt.Sprintf("instances/%s/plugins", r.Stack)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&StackPlugin{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to install Grafana Cloud stack plugin"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*StackPlugin), nil
+}
+
+func (c *Client) ListStackPlugins(ctx context.Context, stack string) (*ListStackPluginsOutput, error) {
+	url := fmt.Sprintf("instances/%s/plugins", stack)
+	resp, err := c.client.R().
+		SetResult(&ListStackPluginsOutput{}).
+		SetContext(ctx).
+		Get(url)
+
+	if err := util.HandleError(err, resp, "failed to list Grafana Cloud stack plugins"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*ListStackPluginsOutput), nil
+}
+
+func (c *Client) UpdateStackPlugin(ctx context.Context, r *UpdateStackPluginInput) (*StackPlugin, error) {
+	url := fmt.Sprintf("instances/%s/plugins/%s", r.Stack, r.Plugin)
+	resp, err := c.client.R().
+		SetBody(r).
+		SetResult(&StackPlugin{}).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to update Grafana Cloud stack plugin"); err != nil {
+		return nil, err
+	}
+
+	return resp.Result().(*StackPlugin), nil
+}
+
+func (c *Client) UninstallStackPlugin(ctx context.Context, stack, plugin string) error {
+	url := fmt.Sprintf("instances/%s/plugins/%s", stack, plugin)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to uninstall Grafana Cloud stack plugin"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l *ListStackPluginsOutput) AddPlugin(p *StackPlugin) {
+	l.Items = append(l.Items, p)
+}
+
+func (l *ListStackPluginsOutput) FindBySlug(slug string) *StackPlugin {
+	for _, p := range l.Items {
+		if p.PluginSlug == slug {
+			return p
+		}
+	}
+
+	return nil
+}
+
+func (l *ListStackPluginsOutput) DeleteBySlug(slug string) {
+	newItems := make([]*StackPlugin, 0)
+
+	for _, p := range l.Items {
+		if p.PluginSlug != slug {
+			newItems = append(newItems, p)
+		}
+	}
+
+	l.Items = newItems
+}
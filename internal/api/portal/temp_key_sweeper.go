@@ -0,0 +1,130 @@
+package portal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+)
+
+// A temporary admin API key left behind on the Grafana instance of a stack, e.g. because deleting it failed.
+type LeakedTempKey struct {
+	Stack      string
+	ID         int
+	Name       string
+	Expiration string
+}
+
+// Finds the temporary admin API keys on all active stacks of the organisation which have either expired or have
+// been created longer than `TempKeyExpires` ago, according to the timestamp in their name, and deletes them unless
+// `dryRun` is set. Keys created by this client are never considered leaked. This creates a temporary key on every
+// stack without credentials configured by `WithStackToken`, so it's meant to run on its own rather than as part of
+// every Terraform run.
+//
+// Keys of all stacks are swept even if sweeping some of them fails, in which case an error is returned together with
+// the keys found.
+func (c *Client) SweepTempKeys(ctx context.Context, orgName string, dryRun bool) ([]*LeakedTempKey, error) {
+	if c.TempKeyPrefix == "" {
+		return nil, fmt.Errorf("temporary keys can't be swept without a prefix identifying them")
+	}
+
+	stacks, err := c.ListStacks(ctx, orgName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*LeakedTempKey, 0)
+	failed := make([]string, 0)
+	for _, stack := range stacks.Items {
+		if stack.Status != StackStatusActive {
+			continue
+		}
+
+		keys, err := c.sweepStackTempKeys(ctx, stack, dryRun)
+		result = append(result, keys...)
+		if err != nil {
+			log.Printf("[WARN] failed sweeping temporary admin API keys on Grafana stack `%s`: %v", stack.Slug, err)
+			failed = append(failed, stack.Slug)
+		}
+	}
+
+	if len(failed) > 0 {
+		return result, fmt.Errorf("failed to sweep temporary admin API keys on stacks %s", strings.Join(failed, ", "))
+	}
+
+	return result, nil
+}
+
+func (c *Client) sweepStackTempKeys(ctx context.Context, stack *Stack, dryRun bool) ([]*LeakedTempKey, error) {
+	client, err := c.getAuthedGrafanaClient(ctx, stack.Slug, func() (*Stack, error) {
+		return stack, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.sweepTempKeysWith(ctx, stack.Slug, client, dryRun)
+}
+
+// Sweeps the temporary keys left behind on a stack with a client for its Grafana API.
+func (c *Client) sweepTempKeysWith(ctx context.Context, stackSlug string, client *grafana.Client, dryRun bool) ([]*LeakedTempKey, error) {
+	keys, err := client.ListAPIKeys(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*LeakedTempKey, 0)
+	for _, key := range keys.Keys {
+		created, ok := c.tempKeyCreated(key.Name)
+		if !ok || c.ownsTempKey(stackSlug, key.ID) {
+			continue
+		}
+
+		expired, err := key.IsExpired()
+		if err != nil {
+			return result, err
+		}
+
+		// Without an expiry, keys might be in use by other runs for any amount of time
+		if !expired && (c.TempKeyExpires <= 0 || time.Since(created) <= c.TempKeyExpires) {
+			continue
+		}
+
+		if !dryRun {
+			if err := client.DeleteAPIKey(ctx, key.ID); err != nil {
+				return result, err
+			}
+
+			log.Printf("[INFO] deleted leaked temporary admin API key `%s` on Grafana stack `%s`", key.Name, stackSlug)
+		}
+
+		result = append(result, &LeakedTempKey{
+			Stack:      stackSlug,
+			ID:         key.ID,
+			Name:       key.Name,
+			Expiration: key.Expiration,
+		})
+	}
+
+	return result, nil
+}
+
+// Temporary keys are named by the prefix and the time of their creation in nanoseconds.
+func (c *Client) tempKeyCreated(name string) (time.Time, bool) {
+	prefix := c.TempKeyPrefix + "-"
+	if !strings.HasPrefix(name, prefix) {
+		return time.Time{}, false
+	}
+
+	nanos, err := strconv.ParseInt(strings.TrimPrefix(name, prefix), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, nanos), true
+}
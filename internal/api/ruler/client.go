@@ -0,0 +1,73 @@
+package ruler
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+)
+
+// Path prefixes of the ruler APIs of the hosted instances of a stack
+const (
+	PrometheusPathPrefix = "api/prom"
+	LokiPathPrefix       = "loki/api/v1"
+)
+
+// Client for the Cortex-compatible ruler APIs of the hosted instances of a stack. These
+// authenticate with the user ID of the hosted instance and a Grafana Cloud API key.
+type Client struct {
+	client *resty.Client
+
+	throttle *util.Throttle
+}
+
+type ClientOpt func(*Client)
+
+func NewClient(baseURL, pathPrefix, userID, apiKey string, opts ...ClientOpt) (*Client, error) {
+	url := strings.TrimSuffix(baseURL, "/") + "/" + pathPrefix
+
+	if !strings.HasSuffix(url, "/") {
+		url = url + "/"
+	}
+
+	resty := resty.New().
+		SetDebug(len(os.Getenv("HTTP_DEBUG")) != 0).
+		SetBasicAuth(userID, apiKey).
+		SetHostURL(url).
+		SetTimeout(30 * time.Second).
+		SetRetryCount(6).
+		AddRetryCondition(util.IsRateLimited)
+
+	c := &Client{
+		client: resty,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.throttle == nil {
+		c.throttle = util.NewThrottle(fmt.Sprintf("ruler API at `%s`", url), 0, 0)
+	}
+
+	c.throttle.Apply(c.client)
+
+	return c, nil
+}
+
+func WithUserAgent(userAgent string) ClientOpt {
+	return func(c *Client) {
+		c.client.SetHeader("User-Agent", userAgent)
+	}
+}
+
+// Throttles the requests of the client by the given throttle, which can be shared with other clients of the
+// same instance. Otherwise, the requests are unlimited.
+func WithThrottle(throttle *util.Throttle) ClientOpt {
+	return func(c *Client) {
+		c.throttle = throttle
+	}
+}
@@ -0,0 +1,118 @@
+package ruler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/util"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule groups are stored in namespaces. All rules of a group are evaluated together at the same
+// interval. Besides the fields of Prometheus rule files, this covers the extensions of the Cortex-based
+// rulers of Grafana Cloud.
+type RuleGroup struct {
+	Name     string `yaml:"name"`
+	Interval string `yaml:"interval,omitempty"`
+
+	// Maximum number of alerts or series an evaluation of the group may produce, unlimited if 0
+	Limit int `yaml:"limit,omitempty"`
+
+	// How long to delay evaluations, e.g. for samples ingested late. `EvaluationDelay` is the name of
+	// `QueryOffset` used by older rulers.
+	QueryOffset     string `yaml:"query_offset,omitempty"`
+	EvaluationDelay string `yaml:"evaluation_delay,omitempty"`
+
+	// Tenants whose data is queried, for federated rule groups
+	SourceTenants []string `yaml:"source_tenants,omitempty"`
+
+	AlignEvaluationTimeOnInterval bool              `yaml:"align_evaluation_time_on_interval,omitempty"`
+	Labels                        map[string]string `yaml:"labels,omitempty"`
+	Rules                         []*Rule           `yaml:"rules"`
+}
+
+// Either a recording or an alerting rule, depending on whether `Record` or `Alert` is set.
+type Rule struct {
+	Record        string            `yaml:"record,omitempty"`
+	Alert         string            `yaml:"alert,omitempty"`
+	Expr          string            `yaml:"expr"`
+	For           string            `yaml:"for,omitempty"`
+	KeepFiringFor string            `yaml:"keep_firing_for,omitempty"`
+	Labels        map[string]string `yaml:"labels,omitempty"`
+	Annotations   map[string]string `yaml:"annotations,omitempty"`
+}
+
+// Returns nil if the namespace doesn't exist.
+func (c *Client) ListRuleGroups(ctx context.Context, namespace string) ([]*RuleGroup, error) {
+	url := fmt.Sprintf("rules/%s", url.PathEscape(namespace))
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Get(url)
+
+	if err == nil && resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err := util.HandleError(err, resp, "failed to list rule groups"); err != nil {
+		return nil, err
+	}
+
+	// Rule groups are returned by namespace
+	namespaces := make(map[string][]*RuleGroup)
+	if err := yaml.Unmarshal(resp.Body(), &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to parse rule groups: %v", err)
+	}
+
+	return namespaces[namespace], nil
+}
+
+// Creates or replaces the rule group with the same name in the namespace. The namespace is created
+// if it doesn't exist yet.
+func (c *Client) SetRuleGroup(ctx context.Context, namespace string, group *RuleGroup) error {
+	body, err := yaml.Marshal(group)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("rules/%s", url.PathEscape(namespace))
+	resp, err := c.client.R().
+		SetHeader("Content-Type", "application/yaml").
+		SetBody(body).
+		SetContext(ctx).
+		Post(url)
+
+	if err := util.HandleError(err, resp, "failed to save rule group"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteRuleGroup(ctx context.Context, namespace, name string) error {
+	url := fmt.Sprintf("rules/%s/%s", url.PathEscape(namespace), url.PathEscape(name))
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete rule group"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Deletes the namespace with all of its rule groups.
+func (c *Client) DeleteNamespace(ctx context.Context, namespace string) error {
+	url := fmt.Sprintf("rules/%s", url.PathEscape(namespace))
+	resp, err := c.client.R().
+		SetContext(ctx).
+		Delete(url)
+
+	if err := util.HandleError(err, resp, "failed to delete rule namespace"); err != nil {
+		return err
+	}
+
+	return nil
+}
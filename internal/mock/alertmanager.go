@@ -0,0 +1,114 @@
+package mock
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/alertmanager"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+	"gopkg.in/yaml.v3"
+)
+
+// The parts of Alertmanager configuration files validated by the mock
+type alertmanagerConfigFile struct {
+	Route *struct {
+		Receiver string `yaml:"receiver"`
+	} `yaml:"route"`
+	Receivers []struct {
+		Name string `yaml:"name"`
+	} `yaml:"receivers"`
+}
+
+// Returns the stack in the request URL if the request is authenticated with the user ID of its
+// Alertmanager instance. Otherwise this sends an error response and returns nil.
+func (g *GrafanaCloud) alertmanagerStack(w http.ResponseWriter, r *http.Request) *portal.Stack {
+	stackSlug := chi.URLParam(r, "stack")
+	stack := g.stackOrganisation(stackSlug).stackList.FindBySlug(stackSlug)
+	if stack == nil {
+		sendResponse(w, &errorResponse{Message: "Not found"}, http.StatusNotFound)
+		return nil
+	}
+
+	user, password, ok := r.BasicAuth()
+	if !ok || user != strconv.Itoa(stack.AmInstanceID) || password == "" {
+		sendResponse(w, &errorResponse{Message: "authentication required"}, http.StatusUnauthorized)
+		return nil
+	}
+
+	return stack
+}
+
+func (g *GrafanaCloud) getAlertmanagerConfig(w http.ResponseWriter, r *http.Request) {
+	stack := g.alertmanagerStack(w, r)
+	if stack == nil {
+		return
+	}
+
+	config, ok := g.stackOrganisation(stack.Slug).alertmanagerConfigs[stack.Slug]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "alertmanager storage object not found"}, http.StatusNotFound)
+		return
+	}
+
+	sendYAML(w, config, http.StatusOK)
+}
+
+func (g *GrafanaCloud) setAlertmanagerConfig(w http.ResponseWriter, r *http.Request) {
+	stack := g.alertmanagerStack(w, r)
+	if stack == nil {
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	config := &alertmanager.Config{}
+	if err := yaml.Unmarshal(body, config); err != nil {
+		sendResponse(w, &errorResponse{Message: fmt.Sprintf("invalid configuration: %v", err)}, http.StatusBadRequest)
+		return
+	}
+
+	if err := validateAlertmanagerConfigFile(config.AlertmanagerConfig); err != nil {
+		sendResponse(w, &errorResponse{Message: fmt.Sprintf("invalid Alertmanager configuration: %v", err)}, http.StatusBadRequest)
+		return
+	}
+
+	g.stackOrganisation(stack.Slug).alertmanagerConfigs[stack.Slug] = config
+	sendResponse(w, nil, http.StatusCreated)
+}
+
+func (g *GrafanaCloud) deleteAlertmanagerConfig(w http.ResponseWriter, r *http.Request) {
+	stack := g.alertmanagerStack(w, r)
+	if stack == nil {
+		return
+	}
+
+	delete(g.stackOrganisation(stack.Slug).alertmanagerConfigs, stack.Slug)
+	sendResponse(w, nil, http.StatusOK)
+}
+
+// Like Cortex, the root route must send alerts to a receiver which is defined
+func validateAlertmanagerConfigFile(content string) error {
+	file := &alertmanagerConfigFile{}
+	if err := yaml.Unmarshal([]byte(content), file); err != nil {
+		return err
+	}
+
+	if file.Route == nil || file.Route.Receiver == "" {
+		return fmt.Errorf("root route must specify a default receiver")
+	}
+
+	for _, receiver := range file.Receivers {
+		if receiver.Name == file.Route.Receiver {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("undefined receiver %q used in route", file.Route.Receiver)
+}
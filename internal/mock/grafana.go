@@ -1,25 +1,1138 @@
 package mock
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
 )
 
+type grafanaInstance struct {
+	apiKeys              *grafana.ListAPIKeysOutput
+	serviceAccounts      *grafana.ListServiceAccountsOutput
+	serviceAccountTokens map[int]*grafana.ListServiceAccountTokensOutput
+
+	// Dashboards by UID
+	dashboards map[string]*grafana.Dashboard
+
+	// Folders and their permissions by UID
+	folders           map[string]*grafana.Folder
+	folderPermissions map[string]*grafana.ListFolderPermissionsOutput
+
+	// Data sources by UID
+	datasources map[string]*grafana.Datasource
+
+	// Alert rule groups by folder UID and title, see ruleGroupKey
+	ruleGroups map[string]*grafana.RuleGroup
+
+	// Contact points keep the values of their secure settings, which are redacted in responses
+	contactPoints      *grafana.ListContactPointsOutput
+	notificationPolicy *grafana.NotificationPolicy
+
+	// Mute timings by name
+	muteTimings map[string]*grafana.MuteTiming
+}
+
+// Like Grafana, every instance comes with a default contact point all alerts are routed to
+const defaultContactPoint = "grafana-default-email"
+
+// Settings of contact points by type, whose values Grafana never returns
+var secureContactPointSettings = map[string][]string{
+	"slack":     {"url", "token"},
+	"pagerduty": {"integrationKey"},
+	"webhook":   {"password"},
+}
+
+func defaultNotificationPolicy() *grafana.NotificationPolicy {
+	return &grafana.NotificationPolicy{
+		Receiver: defaultContactPoint,
+		GroupBy:  []string{"grafana_folder", "alertname"},
+	}
+}
+
+func newGrafanaInstance() *grafanaInstance {
+	return &grafanaInstance{
+		apiKeys:              &grafana.ListAPIKeysOutput{},
+		serviceAccounts:      &grafana.ListServiceAccountsOutput{},
+		serviceAccountTokens: make(map[int]*grafana.ListServiceAccountTokensOutput),
+		dashboards:           make(map[string]*grafana.Dashboard),
+		folders:              make(map[string]*grafana.Folder),
+		folderPermissions:    make(map[string]*grafana.ListFolderPermissionsOutput),
+		datasources:          make(map[string]*grafana.Datasource),
+		ruleGroups:           make(map[string]*grafana.RuleGroup),
+		contactPoints: &grafana.ListContactPointsOutput{
+			Items: []*grafana.ContactPoint{
+				{
+					UID:      "default-email",
+					Name:     defaultContactPoint,
+					Type:     "email",
+					Settings: map[string]interface{}{"addresses": "<example@email.com>"},
+				},
+			},
+		},
+		notificationPolicy: defaultNotificationPolicy(),
+		muteTimings:        make(map[string]*grafana.MuteTiming),
+	}
+}
+
+// Returns the Grafana instance of the stack in the request URL. If there's no such stack, this
+// sends a 404 response and returns nil.
+func (g *GrafanaCloud) grafanaInstance(w http.ResponseWriter, r *http.Request) *grafanaInstance {
+	stack := chi.URLParam(r, "stack")
+	instance, ok := g.stackOrganisation(stack).grafanaInstances[stack]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "Not found"}, http.StatusNotFound)
+		return nil
+	}
+
+	// Routes of the Grafana API itself require a token of the instance, unlike the Grafana Cloud API
+	// routes proxying to it
+	if strings.HasPrefix(r.URL.Path, "/api/grafana/") && !g.authenticatesGrafanaInstance(r, stack, instance) {
+		sendResponse(w, &errorResponse{Message: "invalid API key"}, http.StatusUnauthorized)
+		return nil
+	}
+
+	return instance
+}
+
+// Grafana instances accept their API keys and service account tokens, as well as access policy
+// tokens with a realm covering their stack.
+func (g *GrafanaCloud) authenticatesGrafanaInstance(r *http.Request, stackSlug string, instance *grafanaInstance) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return false
+	}
+
+	for _, key := range instance.apiKeys.Keys {
+		if expired, _ := key.IsExpired(); key.Key == token && !expired {
+			return true
+		}
+	}
+
+	for _, tokens := range instance.serviceAccountTokens {
+		for _, t := range tokens.Items {
+			if t.Key == token {
+				return true
+			}
+		}
+	}
+
+	// Access policies of other organisations only cover the stack by a stack realm
+	org := g.stackOrganisation(stackSlug)
+	stack := org.stackList.FindBySlug(stackSlug)
+	for _, policyOrg := range g.organisations {
+		for region, tokens := range policyOrg.accessPolicyTokens {
+			for _, t := range tokens.Items {
+				if t.Token != token {
+					continue
+				}
+
+				policy := policyOrg.accessPolicies[region].FindByID(t.AccessPolicyID)
+				if policy == nil {
+					continue
+				}
+
+				for _, realm := range policy.Realms {
+					if (realm.Type == portal.AccessPolicyRealmOrg && policyOrg == org) || realm.Identifier == strconv.Itoa(stack.ID) {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns the folder with the UID in the request URL. If there's no such folder, this sends a 404
+// response and returns nil.
+func (g *GrafanaCloud) folder(w http.ResponseWriter, r *http.Request, instance *grafanaInstance) *grafana.Folder {
+	folder, ok := instance.folders[chi.URLParam(r, "uid")]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "folder not found"}, http.StatusNotFound)
+		return nil
+	}
+
+	return folder
+}
+
+// Returns the service account with the ID in the request URL. If there's no such service account,
+// this sends a 404 response and returns nil.
+func (g *GrafanaCloud) serviceAccount(w http.ResponseWriter, r *http.Request, instance *grafanaInstance) *grafana.ServiceAccount {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, err)
+		return nil
+	}
+
+	serviceAccount := instance.serviceAccounts.FindByID(id)
+	if serviceAccount == nil {
+		sendResponse(w, &errorResponse{Message: "service account not found"}, http.StatusNotFound)
+		return nil
+	}
+
+	return serviceAccount
+}
+
 func (g *GrafanaCloud) listGrafanaAPIKeys(w http.ResponseWriter, r *http.Request) {
-	stackName := chi.URLParam(r, "stack")
-	sendResponse(w, g.organisation.stackAPIKeys[stackName].Keys, http.StatusOK)
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	sendResponse(w, instance.apiKeys.Keys, http.StatusOK)
 }
 
 func (g *GrafanaCloud) deleteGrafanaAPIKey(w http.ResponseWriter, r *http.Request) {
-	stackName := chi.URLParam(r, "stack")
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	keyID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	instance.apiKeys.DeleteByID(keyID)
+	sendResponse(w, nil, http.StatusNoContent)
+}
+
+func (g *GrafanaCloud) createServiceAccount(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	input := &grafana.CreateServiceAccountInput{}
+	fromJSON(input, r)
+
+	id := g.GetNextID()
+	serviceAccount := &grafana.ServiceAccount{
+		ID:         id,
+		OrgID:      1,
+		Name:       input.Name,
+		Login:      "sa-" + input.Name,
+		Role:       input.Role,
+		IsDisabled: input.IsDisabled,
+	}
+
+	instance.serviceAccounts.AddServiceAccount(serviceAccount)
+	instance.serviceAccountTokens[id] = &grafana.ListServiceAccountTokensOutput{}
+	sendResponse(w, serviceAccount, http.StatusCreated)
+}
+
+func (g *GrafanaCloud) searchServiceAccounts(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	query := r.URL.Query().Get("query")
+	result := &grafana.SearchServiceAccountsOutput{
+		ServiceAccounts: make([]*grafana.ServiceAccount, 0),
+		Page:            1,
+		PerPage:         1000,
+	}
+
+	for _, sa := range instance.serviceAccounts.Items {
+		if strings.Contains(sa.Name, query) || strings.Contains(sa.Login, query) {
+			result.ServiceAccounts = append(result.ServiceAccounts, sa)
+		}
+	}
+
+	result.TotalCount = len(result.ServiceAccounts)
+	sendResponse(w, result, http.StatusOK)
+}
+
+func (g *GrafanaCloud) migrateGrafanaAPIKey(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
 	keyID, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
 		sendError(w, err)
 		return
 	}
 
-	g.organisation.stackAPIKeys[stackName].DeleteByID(keyID)
+	apiKey := instance.apiKeys.FindByID(keyID)
+	if apiKey == nil {
+		sendResponse(w, &errorResponse{Message: "API key not found"}, http.StatusNotFound)
+		return
+	}
+
+	// Like Grafana, keep the ID of the API key for the token, so it can be found again
+	id := g.GetNextID()
+	instance.serviceAccounts.AddServiceAccount(&grafana.ServiceAccount{
+		ID:    id,
+		OrgID: 1,
+		Name:  apiKey.Name,
+		Login: "sa-autogen-" + apiKey.Name,
+		Role:  apiKey.Role,
+	})
+
+	instance.serviceAccountTokens[id] = &grafana.ListServiceAccountTokensOutput{}
+	instance.serviceAccountTokens[id].AddToken(&grafana.ServiceAccountToken{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Created:    time.Now().Format(time.RFC3339),
+		Expiration: apiKey.Expiration,
+	})
+
+	instance.apiKeys.DeleteByID(keyID)
+	sendResponse(w, nil, http.StatusOK)
+}
+
+func (g *GrafanaCloud) getServiceAccount(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	serviceAccount := g.serviceAccount(w, r, instance)
+	if serviceAccount == nil {
+		return
+	}
+
+	sendResponse(w, serviceAccount, http.StatusOK)
+}
+
+func (g *GrafanaCloud) updateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	serviceAccount := g.serviceAccount(w, r, instance)
+	if serviceAccount == nil {
+		return
+	}
+
+	input := &grafana.UpdateServiceAccountInput{}
+	fromJSON(input, r)
+
+	serviceAccount.Name = input.Name
+	serviceAccount.Role = input.Role
+	serviceAccount.IsDisabled = input.IsDisabled
+
+	sendResponse(w, serviceAccount, http.StatusOK)
+}
+
+func (g *GrafanaCloud) deleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	serviceAccount := g.serviceAccount(w, r, instance)
+	if serviceAccount == nil {
+		return
+	}
+
+	instance.serviceAccounts.DeleteByID(serviceAccount.ID)
+	delete(instance.serviceAccountTokens, serviceAccount.ID)
+	sendResponse(w, nil, http.StatusOK)
+}
+
+func (g *GrafanaCloud) createServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	serviceAccount := g.serviceAccount(w, r, instance)
+	if serviceAccount == nil {
+		return
+	}
+
+	input := &grafana.CreateServiceAccountTokenInput{}
+	fromJSON(input, r)
+
+	id := g.GetNextID()
+	token := &grafana.ServiceAccountToken{
+		ID:      id,
+		Name:    input.Name,
+		Created: time.Now().Format(time.RFC3339),
+		Key:     fmt.Sprintf("service-account-token-%d", id),
+	}
+
+	if input.SecondsToLive > 0 {
+		expiresAt := time.Now().Add(time.Duration(input.SecondsToLive) * time.Second)
+		token.Expiration = expiresAt.Format(time.RFC3339)
+	}
+
+	instance.serviceAccountTokens[serviceAccount.ID].AddToken(token)
+	sendResponse(w, token, http.StatusOK)
+}
+
+func (g *GrafanaCloud) listServiceAccountTokens(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	serviceAccount := g.serviceAccount(w, r, instance)
+	if serviceAccount == nil {
+		return
+	}
+
+	sendResponse(w, instance.serviceAccountTokens[serviceAccount.ID].Items, http.StatusOK)
+}
+
+func (g *GrafanaCloud) deleteServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	serviceAccount := g.serviceAccount(w, r, instance)
+	if serviceAccount == nil {
+		return
+	}
+
+	tokenID, err := strconv.Atoi(chi.URLParam(r, "tokenID"))
+	if err != nil {
+		sendError(w, err)
+		return
+	}
+
+	instance.serviceAccountTokens[serviceAccount.ID].DeleteByID(tokenID)
+	sendResponse(w, nil, http.StatusOK)
+}
+
+func (g *GrafanaCloud) saveDashboard(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	input := &grafana.SaveDashboardInput{}
+	fromJSON(input, r)
+
+	model := input.Dashboard
+	uid, _ := model["uid"].(string)
+	if uid == "" {
+		uid = fmt.Sprintf("mock-%d", g.GetNextID())
+	}
+
+	// JSON numbers are decoded as float64
+	version, _ := model["version"].(float64)
+
+	if _, ok := instance.folders[input.FolderUID]; input.FolderUID != "" && !ok {
+		sendResponse(w, &errorResponse{Message: "folder not found"}, http.StatusBadRequest)
+		return
+	}
+
+	existing, ok := instance.dashboards[uid]
+	if ok && !input.Overwrite && int(version) != existing.Meta.Version {
+		sendResponse(w, &errorResponse{Message: "The dashboard has been changed by someone else"}, http.StatusPreconditionFailed)
+		return
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	dashboard := &grafana.Dashboard{
+		Dashboard: model,
+		Meta: &grafana.DashboardMeta{
+			Slug:      strings.ToLower(strings.ReplaceAll(fmt.Sprint(model["title"]), " ", "-")),
+			Version:   1,
+			FolderUID: input.FolderUID,
+			Created:   now,
+			Updated:   now,
+		},
+	}
+
+	id := g.GetNextID()
+	if ok {
+		id = int(existing.Dashboard["id"].(float64))
+		dashboard.Meta.Version = existing.Meta.Version + 1
+		dashboard.Meta.Created = existing.Meta.Created
+	}
+
+	dashboard.Meta.URL = fmt.Sprintf("/d/%s/%s", uid, dashboard.Meta.Slug)
+	model["id"] = float64(id)
+	model["uid"] = uid
+	model["version"] = float64(dashboard.Meta.Version)
+	instance.dashboards[uid] = dashboard
+
+	sendResponse(w, &grafana.SaveDashboardOutput{
+		ID:      id,
+		UID:     uid,
+		URL:     dashboard.Meta.URL,
+		Status:  "success",
+		Version: dashboard.Meta.Version,
+		Slug:    dashboard.Meta.Slug,
+	}, http.StatusOK)
+}
+
+func (g *GrafanaCloud) getDashboard(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	dashboard, ok := instance.dashboards[chi.URLParam(r, "uid")]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "Dashboard not found"}, http.StatusNotFound)
+		return
+	}
+
+	sendResponse(w, dashboard, http.StatusOK)
+}
+
+func (g *GrafanaCloud) deleteDashboard(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	uid := chi.URLParam(r, "uid")
+	if _, ok := instance.dashboards[uid]; !ok {
+		sendResponse(w, &errorResponse{Message: "Dashboard not found"}, http.StatusNotFound)
+		return
+	}
+
+	delete(instance.dashboards, uid)
+	sendResponse(w, nil, http.StatusOK)
+}
+
+func (g *GrafanaCloud) createFolder(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	input := &grafana.CreateFolderInput{}
+	fromJSON(input, r)
+
+	id := g.GetNextID()
+	if input.UID == "" {
+		input.UID = fmt.Sprintf("mock-%d", id)
+	}
+
+	if _, ok := instance.folders[input.UID]; ok {
+		sendResponse(w, &errorResponse{Message: "a folder with the same uid already exists"}, http.StatusConflict)
+		return
+	}
+
+	folder := &grafana.Folder{
+		ID:      id,
+		UID:     input.UID,
+		Title:   input.Title,
+		URL:     fmt.Sprintf("/dashboards/f/%s", input.UID),
+		Version: 1,
+	}
+
+	// Like Grafana, grant access to viewers and editors by default
+	instance.folders[folder.UID] = folder
+	instance.folderPermissions[folder.UID] = &grafana.ListFolderPermissionsOutput{
+		Items: []*grafana.FolderPermission{
+			{Role: "Viewer", Permission: grafana.FolderPermissionView},
+			{Role: "Editor", Permission: grafana.FolderPermissionEdit},
+		},
+	}
+
+	sendResponse(w, folder, http.StatusOK)
+}
+
+func (g *GrafanaCloud) getFolder(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	folder := g.folder(w, r, instance)
+	if folder == nil {
+		return
+	}
+
+	sendResponse(w, folder, http.StatusOK)
+}
+
+func (g *GrafanaCloud) updateFolder(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	folder := g.folder(w, r, instance)
+	if folder == nil {
+		return
+	}
+
+	input := &grafana.UpdateFolderInput{}
+	fromJSON(input, r)
+
+	folder.Title = input.Title
+	folder.Version++
+	sendResponse(w, folder, http.StatusOK)
+}
+
+func (g *GrafanaCloud) deleteFolder(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	folder := g.folder(w, r, instance)
+	if folder == nil {
+		return
+	}
+
+	for uid, dashboard := range instance.dashboards {
+		if dashboard.Meta.FolderUID == folder.UID {
+			delete(instance.dashboards, uid)
+		}
+	}
+
+	for key, group := range instance.ruleGroups {
+		if group.FolderUID == folder.UID {
+			delete(instance.ruleGroups, key)
+		}
+	}
+
+	delete(instance.folders, folder.UID)
+	delete(instance.folderPermissions, folder.UID)
+	sendResponse(w, nil, http.StatusOK)
+}
+
+func (g *GrafanaCloud) listFolderPermissions(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	folder := g.folder(w, r, instance)
+	if folder == nil {
+		return
+	}
+
+	sendResponse(w, instance.folderPermissions[folder.UID].Items, http.StatusOK)
+}
+
+func (g *GrafanaCloud) updateFolderPermissions(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	folder := g.folder(w, r, instance)
+	if folder == nil {
+		return
+	}
+
+	input := &grafana.UpdateFolderPermissionsInput{}
+	fromJSON(input, r)
+
+	instance.folderPermissions[folder.UID].Items = input.Items
+	sendResponse(w, nil, http.StatusOK)
+}
+
+func (g *GrafanaCloud) createDatasource(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	input := &grafana.CreateDatasourceInput{}
+	fromJSON(input, r)
+
+	for _, ds := range instance.datasources {
+		if ds.Name == input.Name || ds.UID == input.UID {
+			sendResponse(w, &errorResponse{Message: "data source with the same name or uid already exists"}, http.StatusConflict)
+			return
+		}
+	}
+
+	id := g.GetNextID()
+	if input.UID == "" {
+		input.UID = fmt.Sprintf("mock-%d", id)
+	}
+
+	datasource := &grafana.Datasource{
+		ID:               id,
+		UID:              input.UID,
+		OrgID:            1,
+		Name:             input.Name,
+		Type:             input.Type,
+		URL:              input.URL,
+		Access:           input.Access,
+		IsDefault:        input.IsDefault,
+		BasicAuth:        input.BasicAuth,
+		BasicAuthUser:    input.BasicAuthUser,
+		JSONData:         input.JSONData,
+		SecureJSONFields: secureJSONFields(nil, input.SecureJSONData),
+	}
+
+	instance.datasources[datasource.UID] = datasource
+	sendResponse(w, &grafana.DatasourceOutput{
+		ID:         datasource.ID,
+		Name:       datasource.Name,
+		Message:    "Datasource added",
+		Datasource: datasource,
+	}, http.StatusOK)
+}
+
+func (g *GrafanaCloud) getDatasource(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	datasource, ok := instance.datasources[chi.URLParam(r, "uid")]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "Data source not found"}, http.StatusNotFound)
+		return
+	}
+
+	sendResponse(w, datasource, http.StatusOK)
+}
+
+func (g *GrafanaCloud) updateDatasource(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	datasource, ok := instance.datasources[chi.URLParam(r, "uid")]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "Data source not found"}, http.StatusNotFound)
+		return
+	}
+
+	input := &grafana.UpdateDatasourceInput{}
+	fromJSON(input, r)
+
+	datasource.Name = input.Name
+	datasource.Type = input.Type
+	datasource.URL = input.URL
+	datasource.Access = input.Access
+	datasource.IsDefault = input.IsDefault
+	datasource.BasicAuth = input.BasicAuth
+	datasource.BasicAuthUser = input.BasicAuthUser
+	datasource.JSONData = input.JSONData
+	datasource.SecureJSONFields = secureJSONFields(datasource.SecureJSONFields, input.SecureJSONData)
+
+	sendResponse(w, &grafana.DatasourceOutput{
+		ID:         datasource.ID,
+		Name:       datasource.Name,
+		Message:    "Datasource updated",
+		Datasource: datasource,
+	}, http.StatusOK)
+}
+
+func (g *GrafanaCloud) deleteDatasource(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	uid := chi.URLParam(r, "uid")
+	if _, ok := instance.datasources[uid]; !ok {
+		sendResponse(w, &errorResponse{Message: "Data source not found"}, http.StatusNotFound)
+		return
+	}
+
+	delete(instance.datasources, uid)
+	sendResponse(w, nil, http.StatusOK)
+}
+
+// Like Grafana, only keep track of which secure fields are set, never of their values.
+func secureJSONFields(existing map[string]bool, data map[string]string) map[string]bool {
+	result := make(map[string]bool)
+	for k, v := range existing {
+		result[k] = v
+	}
+
+	// Like Grafana, empty values clear the setting
+	for k, v := range data {
+		if v == "" {
+			delete(result, k)
+		} else {
+			result[k] = true
+		}
+	}
+
+	return result
+}
+
+func (g *GrafanaCloud) getRuleGroup(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	group, ok := instance.ruleGroups[ruleGroupKey(r)]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "rule group not found"}, http.StatusNotFound)
+		return
+	}
+
+	sendResponse(w, group, http.StatusOK)
+}
+
+func (g *GrafanaCloud) putRuleGroup(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	folder := g.folder(w, r, instance)
+	if folder == nil {
+		return
+	}
+
+	input := &grafana.RuleGroup{}
+	fromJSON(input, r)
+
+	title, _ := url.PathUnescape(chi.URLParam(r, "group"))
+	if input.Interval <= 0 || input.Interval%10 != 0 {
+		sendResponse(w, &errorResponse{Message: "interval must be a positive multiple of 10 seconds"}, http.StatusBadRequest)
+		return
+	}
+
+	existingIDs := make(map[string]int)
+	if existing, ok := instance.ruleGroups[ruleGroupKey(r)]; ok {
+		for _, rule := range existing.Rules {
+			existingIDs[rule.UID] = rule.ID
+		}
+	}
+
+	for _, rule := range input.Rules {
+		id, ok := existingIDs[rule.UID]
+		if !ok {
+			id = g.GetNextID()
+		}
+
+		if rule.UID == "" {
+			rule.UID = fmt.Sprintf("mock-%d", id)
+		}
+
+		rule.ID = id
+		rule.OrgID = 1
+		rule.FolderUID = folder.UID
+		rule.RuleGroup = title
+
+		// Like Grafana, add the default query options to the models
+		for _, query := range rule.Data {
+			if query.Model == nil {
+				query.Model = make(map[string]interface{})
+			}
+
+			if _, ok := query.Model["intervalMs"]; !ok {
+				query.Model["intervalMs"] = float64(1000)
+			}
+
+			if _, ok := query.Model["maxDataPoints"]; !ok {
+				query.Model["maxDataPoints"] = float64(43200)
+			}
+		}
+	}
+
+	input.Title = title
+	input.FolderUID = folder.UID
+	instance.ruleGroups[ruleGroupKey(r)] = input
+	sendResponse(w, input, http.StatusOK)
+}
+
+func (g *GrafanaCloud) deleteRuleGroup(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	key := ruleGroupKey(r)
+	if _, ok := instance.ruleGroups[key]; !ok {
+		sendResponse(w, &errorResponse{Message: "rule group not found"}, http.StatusNotFound)
+		return
+	}
+
+	delete(instance.ruleGroups, key)
+	sendResponse(w, nil, http.StatusNoContent)
+}
+
+func ruleGroupKey(r *http.Request) string {
+	group, _ := url.PathUnescape(chi.URLParam(r, "group"))
+	return chi.URLParam(r, "uid") + "/" + group
+}
+
+func (g *GrafanaCloud) createContactPoint(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	input := &grafana.ContactPoint{}
+	fromJSON(input, r)
+
+	if input.UID == "" {
+		input.UID = fmt.Sprintf("mock-%d", g.GetNextID())
+	}
+
+	if instance.contactPoints.FindByUID(input.UID) != nil {
+		sendResponse(w, &errorResponse{Message: "a contact point with the same uid already exists"}, http.StatusConflict)
+		return
+	}
+
+	instance.contactPoints.Items = append(instance.contactPoints.Items, input)
+	sendResponse(w, redactContactPoint(input), http.StatusAccepted)
+}
+
+func (g *GrafanaCloud) listContactPoints(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	contactPoints := instance.contactPoints.Items
+	if name := r.URL.Query().Get("name"); name != "" {
+		contactPoints = instance.contactPoints.FilterByName(name)
+	}
+
+	result := make([]*grafana.ContactPoint, 0, len(contactPoints))
+	for _, cp := range contactPoints {
+		result = append(result, redactContactPoint(cp))
+	}
+
+	sendResponse(w, result, http.StatusOK)
+}
+
+func (g *GrafanaCloud) updateContactPoint(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	contactPoint := instance.contactPoints.FindByUID(chi.URLParam(r, "uid"))
+	if contactPoint == nil {
+		sendResponse(w, &errorResponse{Message: "contact point not found"}, http.StatusNotFound)
+		return
+	}
+
+	input := &grafana.ContactPoint{}
+	fromJSON(input, r)
+
+	// Redacted secure settings are left unchanged
+	for k, v := range input.Settings {
+		if v == grafana.RedactedSetting {
+			input.Settings[k] = contactPoint.Settings[k]
+		}
+	}
+
+	contactPoint.Name = input.Name
+	contactPoint.Type = input.Type
+	contactPoint.Settings = input.Settings
+	contactPoint.DisableResolveMessage = input.DisableResolveMessage
+	sendResponse(w, redactContactPoint(contactPoint), http.StatusAccepted)
+}
+
+func (g *GrafanaCloud) deleteContactPoint(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	contactPoint := instance.contactPoints.FindByUID(chi.URLParam(r, "uid"))
+	if contactPoint == nil {
+		sendResponse(w, &errorResponse{Message: "contact point not found"}, http.StatusNotFound)
+		return
+	}
+
+	// The last integration of a contact point can't be deleted while it's in use
+	inUse := policyReferences(instance.notificationPolicy, func(p *grafana.NotificationPolicy) []string {
+		return []string{p.Receiver}
+	})
+
+	if len(instance.contactPoints.FilterByName(contactPoint.Name)) == 1 && inUse[contactPoint.Name] {
+		sendResponse(w, &errorResponse{Message: "contact point is referenced by a notification policy"}, http.StatusConflict)
+		return
+	}
+
+	instance.contactPoints.DeleteByUID(contactPoint.UID)
+	sendResponse(w, nil, http.StatusAccepted)
+}
+
+func (g *GrafanaCloud) getNotificationPolicy(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	sendResponse(w, instance.notificationPolicy, http.StatusOK)
+}
+
+func (g *GrafanaCloud) setNotificationPolicy(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	input := &grafana.NotificationPolicy{}
+	fromJSON(input, r)
+
+	if input.Receiver == "" {
+		sendResponse(w, &errorResponse{Message: "the root policy must have a contact point"}, http.StatusBadRequest)
+		return
+	}
+
+	receivers := policyReferences(input, func(p *grafana.NotificationPolicy) []string {
+		return []string{p.Receiver}
+	})
+
+	for receiver := range receivers {
+		if len(instance.contactPoints.FilterByName(receiver)) == 0 {
+			sendResponse(w, &errorResponse{Message: fmt.Sprintf("contact point `%s` does not exist", receiver)}, http.StatusBadRequest)
+			return
+		}
+	}
+
+	muteTimings := policyReferences(input, func(p *grafana.NotificationPolicy) []string {
+		return p.MuteTimeIntervals
+	})
+
+	for muteTiming := range muteTimings {
+		if _, ok := instance.muteTimings[muteTiming]; !ok {
+			sendResponse(w, &errorResponse{Message: fmt.Sprintf("mute timing `%s` does not exist", muteTiming)}, http.StatusBadRequest)
+			return
+		}
+	}
+
+	instance.notificationPolicy = input
+	sendResponse(w, nil, http.StatusAccepted)
+}
+
+func (g *GrafanaCloud) resetNotificationPolicy(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	instance.notificationPolicy = defaultNotificationPolicy()
+	sendResponse(w, instance.notificationPolicy, http.StatusAccepted)
+}
+
+func (g *GrafanaCloud) createMuteTiming(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	input := &grafana.MuteTiming{}
+	fromJSON(input, r)
+
+	if _, ok := instance.muteTimings[input.Name]; ok {
+		sendResponse(w, &errorResponse{Message: "a mute timing with the same name already exists"}, http.StatusConflict)
+		return
+	}
+
+	instance.muteTimings[input.Name] = input
+	sendResponse(w, input, http.StatusCreated)
+}
+
+func (g *GrafanaCloud) getMuteTiming(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	muteTiming, ok := instance.muteTimings[muteTimingName(r)]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "mute timing not found"}, http.StatusNotFound)
+		return
+	}
+
+	sendResponse(w, muteTiming, http.StatusOK)
+}
+
+func (g *GrafanaCloud) updateMuteTiming(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	name := muteTimingName(r)
+	if _, ok := instance.muteTimings[name]; !ok {
+		sendResponse(w, &errorResponse{Message: "mute timing not found"}, http.StatusNotFound)
+		return
+	}
+
+	input := &grafana.MuteTiming{}
+	fromJSON(input, r)
+
+	input.Name = name
+	instance.muteTimings[name] = input
+	sendResponse(w, input, http.StatusAccepted)
+}
+
+func (g *GrafanaCloud) deleteMuteTiming(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	name := muteTimingName(r)
+	if _, ok := instance.muteTimings[name]; !ok {
+		sendResponse(w, &errorResponse{Message: "mute timing not found"}, http.StatusNotFound)
+		return
+	}
+
+	inUse := policyReferences(instance.notificationPolicy, func(p *grafana.NotificationPolicy) []string {
+		return p.MuteTimeIntervals
+	})
+
+	if inUse[name] {
+		sendResponse(w, &errorResponse{Message: "mute timing is referenced by a notification policy"}, http.StatusConflict)
+		return
+	}
+
+	delete(instance.muteTimings, name)
 	sendResponse(w, nil, http.StatusNoContent)
 }
+
+func muteTimingName(r *http.Request) string {
+	name, _ := url.PathUnescape(chi.URLParam(r, "name"))
+	return name
+}
+
+// Returns a copy of the contact point with the values of its secure settings redacted.
+func redactContactPoint(cp *grafana.ContactPoint) *grafana.ContactPoint {
+	result := *cp
+	result.Settings = make(map[string]interface{})
+	for k, v := range cp.Settings {
+		result.Settings[k] = v
+	}
+
+	for _, k := range secureContactPointSettings[cp.Type] {
+		if _, ok := result.Settings[k]; ok {
+			result.Settings[k] = grafana.RedactedSetting
+		}
+	}
+
+	return &result
+}
+
+// Collects the names referenced by the policy and all of its child policies.
+func policyReferences(policy *grafana.NotificationPolicy, refs func(*grafana.NotificationPolicy) []string) map[string]bool {
+	result := make(map[string]bool)
+	for _, ref := range refs(policy) {
+		if ref != "" {
+			result[ref] = true
+		}
+	}
+
+	for _, route := range policy.Routes {
+		for ref := range policyReferences(route, refs) {
+			result[ref] = true
+		}
+	}
+
+	return result
+}
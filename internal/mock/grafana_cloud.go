@@ -3,6 +3,8 @@ package mock
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -10,6 +12,16 @@ import (
 	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
 )
 
+const (
+	defaultRegion = "us"
+
+	// Newly created stacks are reported as starting for this many list requests before becoming active
+	stackStartingPolls = 2
+
+	// Version plugins are installed with if none is given
+	latestPluginVersion = "2.0.0"
+)
+
 func (g *GrafanaCloud) createPortalAPIKey(w http.ResponseWriter, r *http.Request) {
 	apiKey := &portal.APIKey{
 		ID:    g.GetNextID(),
@@ -17,17 +29,32 @@ func (g *GrafanaCloud) createPortalAPIKey(w http.ResponseWriter, r *http.Request
 	}
 	fromJSON(apiKey, r)
 
-	g.organisation.portalAPIKeys.AddKey(apiKey)
+	org := g.requestOrganisation(w, r)
+	if org == nil {
+		return
+	}
+
+	org.portalAPIKeys.AddKey(apiKey)
 	sendResponse(w, apiKey, http.StatusCreated)
 }
 
 func (g *GrafanaCloud) listPortalAPIKeys(w http.ResponseWriter, r *http.Request) {
-	sendResponse(w, g.organisation.portalAPIKeys, http.StatusOK)
+	org := g.requestOrganisation(w, r)
+	if org == nil {
+		return
+	}
+
+	sendResponse(w, org.portalAPIKeys, http.StatusOK)
 }
 
 func (g *GrafanaCloud) deletePortalAPIKey(w http.ResponseWriter, r *http.Request) {
+	org := g.requestOrganisation(w, r)
+	if org == nil {
+		return
+	}
+
 	name := chi.URLParam(r, "name")
-	g.organisation.portalAPIKeys.DeleteByName(name)
+	org.portalAPIKeys.DeleteByName(name)
 	sendResponse(w, nil, http.StatusNoContent)
 }
 
@@ -36,11 +63,12 @@ func (g *GrafanaCloud) createGrafanaAPIKeyProxy(w http.ResponseWriter, r *http.R
 	input := &portal.CreateGrafanaAPIKeyInput{}
 	fromJSON(input, r)
 
+	id := g.GetNextID()
 	apiKey := &grafana.APIKey{
 		Name: input.Name,
 		Role: input.Role,
-		ID:   g.GetNextID(),
-		Key:  "very-secret",
+		ID:   id,
+		Key:  fmt.Sprintf("api-key-%d", id),
 	}
 
 	if input.SecondsToLive > 0 {
@@ -48,38 +76,449 @@ func (g *GrafanaCloud) createGrafanaAPIKeyProxy(w http.ResponseWriter, r *http.R
 		apiKey.Expiration = expiresAt.Format(time.RFC3339)
 	}
 
-	g.organisation.stackAPIKeys[stackName].AddKey(apiKey)
+	g.stackOrganisation(stackName).grafanaInstances[stackName].apiKeys.AddKey(apiKey)
 	sendResponse(w, apiKey, http.StatusCreated)
 }
 
 func (g *GrafanaCloud) listStacks(w http.ResponseWriter, r *http.Request) {
-	sendResponse(w, g.organisation.stackList, http.StatusOK)
+	org := g.requestOrganisation(w, r)
+	if org == nil {
+		return
+	}
+
+	for _, stack := range org.stackList.Items {
+		if stack.Status != portal.StackStatusStarting {
+			continue
+		}
+
+		org.stackPolls[stack.Slug] += 1
+		if org.stackPolls[stack.Slug] > stackStartingPolls {
+			stack.Status = portal.StackStatusActive
+			delete(org.stackPolls, stack.Slug)
+		}
+	}
+
+	sendResponse(w, org.stackList, http.StatusOK)
 }
 
 func (g *GrafanaCloud) createStack(w http.ResponseWriter, r *http.Request) {
+	input := &portal.CreateStackInput{}
+	fromJSON(input, r)
+
+	org := g.organisation
+	if input.Org != "" {
+		var ok bool
+		if org, ok = g.organisations[input.Org]; !ok {
+			sendResponse(w, &errorResponse{Message: "organisation not found"}, http.StatusNotFound)
+			return
+		}
+	}
+
 	stack := &portal.Stack{
-		HmInstancePromID:  g.GetNextID(),
-		HmInstancePromURL: "https://prometheus-instance",
-		AmInstanceID:      g.GetNextID(),
+		Name:                  input.Name,
+		Slug:                  input.Slug,
+		URL:                   input.URL,
+		Description:           input.Description,
+		RegionSlug:            input.Region,
+		Labels:                input.Labels,
+		Status:                portal.StackStatusStarting,
+		HmInstancePromID:      g.GetNextID(),
+		HmInstancePromURL:     fmt.Sprintf("%s/prometheus/%s", g.URL(), input.Slug),
+		HmInstanceGraphiteID:  g.GetNextID(),
+		HmInstanceGraphiteURL: "https://graphite-instance",
+		HlInstanceID:          g.GetNextID(),
+		HlInstanceURL:         fmt.Sprintf("%s/loki/%s", g.URL(), input.Slug),
+		HtInstanceID:          g.GetNextID(),
+		HtInstanceURL:         "https://traces-instance",
+		HpInstanceID:          g.GetNextID(),
+		HpInstanceURL:         "https://profiles-instance",
+		AmInstanceID:          g.GetNextID(),
+	}
+
+	if stack.RegionSlug == "" {
+		stack.RegionSlug = defaultRegion
 	}
-	fromJSON(stack, r)
 
 	stack.ID = g.GetNextID()
-	stack.OrgID = g.GetNextID()
-	stack.OrgSlug = g.organisation.name
-	stack.OrgName = g.organisation.name
+	stack.OrgID = org.id
+	stack.OrgSlug = org.name
+	stack.OrgName = org.name
 	if stack.URL == "" {
 		stack.URL = fmt.Sprintf("%s/grafana/%s", g.URL(), stack.Slug)
 	}
 
-	g.organisation.stackList.AddStack(stack)
-	g.organisation.stackAPIKeys[stack.Slug] = &grafana.ListAPIKeysOutput{}
+	org.stackList.AddStack(stack)
+	org.grafanaInstances[stack.Slug] = g.newStackGrafanaInstance(stack)
+	org.stackPlugins[stack.Slug] = &portal.ListStackPluginsOutput{}
+	for _, rulers := range org.rulers {
+		rulers[stack.Slug] = newRulerInstance()
+	}
+
 	sendResponse(w, stack, http.StatusCreated)
 }
 
+// Grafana instances of new stacks come with data sources for the hosted instances of the stack
+func (g *GrafanaCloud) newStackGrafanaInstance(stack *portal.Stack) *grafanaInstance {
+	instance := newGrafanaInstance()
+
+	builtins := []struct {
+		suffix, dsType, url string
+		user                int
+	}{
+		{"prom", "prometheus", stack.HmInstancePromURL, stack.HmInstancePromID},
+		{"logs", "loki", stack.HlInstanceURL, stack.HlInstanceID},
+		{"traces", "tempo", stack.HtInstanceURL, stack.HtInstanceID},
+		{"alertmanager", "grafana-alertmanager-datasource", fmt.Sprintf("%s/alertmanager/%s", g.URL(), stack.Slug), stack.AmInstanceID},
+	}
+
+	for _, b := range builtins {
+		datasource := &grafana.Datasource{
+			ID:            g.GetNextID(),
+			UID:           fmt.Sprintf("grafanacloud-%s", b.suffix),
+			OrgID:         1,
+			Name:          fmt.Sprintf("grafanacloud-%s-%s", stack.Slug, b.suffix),
+			Type:          b.dsType,
+			URL:           b.url,
+			Access:        "proxy",
+			BasicAuth:     true,
+			BasicAuthUser: strconv.Itoa(b.user),
+		}
+
+		instance.datasources[datasource.UID] = datasource
+	}
+
+	return instance
+}
+
+func (g *GrafanaCloud) listDatasources(w http.ResponseWriter, r *http.Request) {
+	instance := g.grafanaInstance(w, r)
+	if instance == nil {
+		return
+	}
+
+	stack := chi.URLParam(r, "stack")
+	result := &portal.ListDatasourcesOutput{
+		Items: make([]*portal.Datasource, 0, len(instance.datasources)),
+	}
+
+	for _, ds := range instance.datasources {
+		result.Items = append(result.Items, &portal.Datasource{
+			ID:            ds.ID,
+			UID:           ds.UID,
+			InstanceID:    g.stackOrganisation(stack).stackList.FindBySlug(stack).ID,
+			InstanceSlug:  stack,
+			Name:          ds.Name,
+			Type:          ds.Type,
+			URL:           ds.URL,
+			BasicAuth:     boolToInt(ds.BasicAuth),
+			BasicAuthUser: ds.BasicAuthUser,
+		})
+	}
+
+	// Grafana Cloud lists data sources by ID
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].ID < result.Items[j].ID
+	})
+
+	sendResponse(w, result, http.StatusOK)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func (g *GrafanaCloud) updateStack(w http.ResponseWriter, r *http.Request) {
+	stackSlug := chi.URLParam(r, "stack")
+	stack := g.stackOrganisation(stackSlug).stackList.FindBySlug(stackSlug)
+	if stack == nil {
+		sendResponse(w, &errorResponse{Message: "instance not found"}, http.StatusNotFound)
+		return
+	}
+
+	input := &portal.UpdateStackInput{}
+	fromJSON(input, r)
+
+	stack.Name = input.Name
+	stack.Description = input.Description
+	stack.Labels = input.Labels
+	if input.URL != "" {
+		stack.URL = input.URL
+	}
+
+	sendResponse(w, stack, http.StatusOK)
+}
+
 func (g *GrafanaCloud) deleteStack(w http.ResponseWriter, r *http.Request) {
 	stackSlug := chi.URLParam(r, "stack")
-	g.organisation.stackList.DeleteBySlug(stackSlug)
-	delete(g.organisation.stackAPIKeys, stackSlug)
+	org := g.stackOrganisation(stackSlug)
+	org.stackList.DeleteBySlug(stackSlug)
+	delete(org.grafanaInstances, stackSlug)
+	delete(org.stackPlugins, stackSlug)
+	delete(org.alertmanagerConfigs, stackSlug)
+	for _, rulers := range org.rulers {
+		delete(rulers, stackSlug)
+	}
+
 	sendResponse(w, nil, http.StatusNoContent)
 }
+
+// Returns the plugins of the stack in the request URL. If there's no such stack, this sends a 404
+// response and returns nil.
+func (g *GrafanaCloud) stackPlugins(w http.ResponseWriter, r *http.Request) *portal.ListStackPluginsOutput {
+	stackSlug := chi.URLParam(r, "stack")
+	plugins, ok := g.stackOrganisation(stackSlug).stackPlugins[stackSlug]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "instance not found"}, http.StatusNotFound)
+		return nil
+	}
+
+	return plugins
+}
+
+func (g *GrafanaCloud) installStackPlugin(w http.ResponseWriter, r *http.Request) {
+	plugins := g.stackPlugins(w, r)
+	if plugins == nil {
+		return
+	}
+
+	input := &portal.InstallStackPluginInput{}
+	fromJSON(input, r)
+
+	if plugins.FindBySlug(input.Plugin) != nil {
+		sendResponse(w, &errorResponse{Message: "plugin is already installed"}, http.StatusConflict)
+		return
+	}
+
+	stackSlug := chi.URLParam(r, "stack")
+	stack := g.stackOrganisation(stackSlug).stackList.FindBySlug(stackSlug)
+	now := time.Now().Format(time.RFC3339)
+	plugin := &portal.StackPlugin{
+		ID:            g.GetNextID(),
+		InstanceID:    stack.ID,
+		InstanceSlug:  stack.Slug,
+		PluginID:      g.GetNextID(),
+		PluginSlug:    input.Plugin,
+		PluginName:    input.Plugin,
+		Version:       input.Version,
+		LatestVersion: latestPluginVersion,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if plugin.Version == "" {
+		plugin.Version = latestPluginVersion
+	}
+
+	plugins.AddPlugin(plugin)
+	sendResponse(w, plugin, http.StatusOK)
+}
+
+func (g *GrafanaCloud) listStackPlugins(w http.ResponseWriter, r *http.Request) {
+	plugins := g.stackPlugins(w, r)
+	if plugins == nil {
+		return
+	}
+
+	sendResponse(w, plugins, http.StatusOK)
+}
+
+func (g *GrafanaCloud) updateStackPlugin(w http.ResponseWriter, r *http.Request) {
+	plugins := g.stackPlugins(w, r)
+	if plugins == nil {
+		return
+	}
+
+	plugin := plugins.FindBySlug(chi.URLParam(r, "plugin"))
+	if plugin == nil {
+		sendResponse(w, &errorResponse{Message: "plugin not found"}, http.StatusNotFound)
+		return
+	}
+
+	input := &portal.UpdateStackPluginInput{}
+	fromJSON(input, r)
+
+	plugin.Version = input.Version
+	plugin.UpdatedAt = time.Now().Format(time.RFC3339)
+	sendResponse(w, plugin, http.StatusOK)
+}
+
+func (g *GrafanaCloud) uninstallStackPlugin(w http.ResponseWriter, r *http.Request) {
+	plugins := g.stackPlugins(w, r)
+	if plugins == nil {
+		return
+	}
+
+	plugins.DeleteBySlug(chi.URLParam(r, "plugin"))
+	sendResponse(w, nil, http.StatusNoContent)
+}
+
+func (g *GrafanaCloud) regionAccessPolicies(r *http.Request) *portal.ListAccessPoliciesOutput {
+	region := r.URL.Query().Get("region")
+	if _, ok := g.organisation.accessPolicies[region]; !ok {
+		g.organisation.accessPolicies[region] = &portal.ListAccessPoliciesOutput{}
+	}
+
+	return g.organisation.accessPolicies[region]
+}
+
+func (g *GrafanaCloud) createAccessPolicy(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("region") == "" {
+		sendError(w, fmt.Errorf("region is required"))
+		return
+	}
+
+	input := &portal.CreateAccessPolicyInput{}
+	fromJSON(input, r)
+
+	now := time.Now().Format(time.RFC3339)
+	policy := &portal.AccessPolicy{
+		ID:          strconv.Itoa(g.GetNextID()),
+		OrgID:       strconv.Itoa(g.GetNextID()),
+		Name:        input.Name,
+		DisplayName: input.DisplayName,
+		Scopes:      input.Scopes,
+		Realms:      input.Realms,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if policy.DisplayName == "" {
+		policy.DisplayName = policy.Name
+	}
+
+	g.regionAccessPolicies(r).AddPolicy(policy)
+	sendResponse(w, policy, http.StatusOK)
+}
+
+func (g *GrafanaCloud) listAccessPolicies(w http.ResponseWriter, r *http.Request) {
+	sendResponse(w, g.regionAccessPolicies(r), http.StatusOK)
+}
+
+func (g *GrafanaCloud) updateAccessPolicy(w http.ResponseWriter, r *http.Request) {
+	policy := g.regionAccessPolicies(r).FindByID(chi.URLParam(r, "id"))
+	if policy == nil {
+		sendResponse(w, &errorResponse{Message: "access policy not found"}, http.StatusNotFound)
+		return
+	}
+
+	input := &portal.UpdateAccessPolicyInput{}
+	fromJSON(input, r)
+
+	if input.DisplayName != "" {
+		policy.DisplayName = input.DisplayName
+	}
+
+	policy.Scopes = input.Scopes
+	policy.Realms = input.Realms
+	policy.UpdatedAt = time.Now().Format(time.RFC3339)
+
+	sendResponse(w, policy, http.StatusOK)
+}
+
+func (g *GrafanaCloud) deleteAccessPolicy(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	g.regionAccessPolicies(r).DeleteByID(id)
+	g.regionAccessPolicyTokens(r).DeleteByAccessPolicyID(id)
+	sendResponse(w, nil, http.StatusNoContent)
+}
+
+func (g *GrafanaCloud) regionAccessPolicyTokens(r *http.Request) *portal.ListAccessPolicyTokensOutput {
+	region := r.URL.Query().Get("region")
+	if _, ok := g.organisation.accessPolicyTokens[region]; !ok {
+		g.organisation.accessPolicyTokens[region] = &portal.ListAccessPolicyTokensOutput{}
+	}
+
+	return g.organisation.accessPolicyTokens[region]
+}
+
+func (g *GrafanaCloud) createAccessPolicyToken(w http.ResponseWriter, r *http.Request) {
+	input := &portal.CreateAccessPolicyTokenInput{}
+	fromJSON(input, r)
+
+	if g.regionAccessPolicies(r).FindByID(input.AccessPolicyID) == nil {
+		sendError(w, fmt.Errorf("access policy %s not found", input.AccessPolicyID))
+		return
+	}
+
+	id := strconv.Itoa(g.GetNextID())
+	token := &portal.AccessPolicyToken{
+		ID:             id,
+		AccessPolicyID: input.AccessPolicyID,
+		Name:           input.Name,
+		DisplayName:    input.DisplayName,
+		ExpiresAt:      input.ExpiresAt,
+		CreatedAt:      time.Now().Format(time.RFC3339),
+		Token:          fmt.Sprintf("access-policy-token-%s", id),
+	}
+
+	if token.DisplayName == "" {
+		token.DisplayName = token.Name
+	}
+
+	g.regionAccessPolicyTokens(r).AddToken(token)
+	sendResponse(w, token, http.StatusOK)
+}
+
+func (g *GrafanaCloud) listAccessPolicyTokens(w http.ResponseWriter, r *http.Request) {
+	accessPolicyID := r.URL.Query().Get("accessPolicyId")
+	resp := &portal.ListAccessPolicyTokensOutput{}
+
+	for _, t := range g.regionAccessPolicyTokens(r).Items {
+		if accessPolicyID == "" || t.AccessPolicyID == accessPolicyID {
+			resp.AddToken(t)
+		}
+	}
+
+	sendResponse(w, resp, http.StatusOK)
+}
+
+func (g *GrafanaCloud) deleteAccessPolicyToken(w http.ResponseWriter, r *http.Request) {
+	g.regionAccessPolicyTokens(r).DeleteByID(chi.URLParam(r, "id"))
+	sendResponse(w, nil, http.StatusNoContent)
+}
+
+func (g *GrafanaCloud) getHostedInstance(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+
+		for _, stack := range g.stacks() {
+			instance := &portal.HostedInstance{
+				OrgID:       stack.OrgID,
+				OrgSlug:     stack.OrgSlug,
+				Name:        fmt.Sprintf("%s-%s", stack.Slug, kind),
+				Status:      portal.StackStatusActive,
+				ClusterID:   1,
+				ClusterSlug: fmt.Sprintf("prod-%s-0", stack.RegionSlug),
+				ClusterName: fmt.Sprintf("prod-%s-0", stack.RegionSlug),
+			}
+
+			switch {
+			case kind == portal.HostedMetrics && stack.HmInstancePromID == id:
+				instance.Type = "prometheus"
+				instance.URL = stack.HmInstancePromURL
+			case kind == portal.HostedLogs && stack.HlInstanceID == id:
+				instance.Type = "logs"
+				instance.URL = stack.HlInstanceURL
+			case kind == portal.HostedTraces && stack.HtInstanceID == id:
+				instance.Type = "traces"
+				instance.URL = stack.HtInstanceURL
+			default:
+				continue
+			}
+
+			instance.ID = id
+			sendResponse(w, instance, http.StatusOK)
+			return
+		}
+
+		sendResponse(w, &errorResponse{Message: "instance not found"}, http.StatusNotFound)
+	}
+}
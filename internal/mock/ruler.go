@@ -0,0 +1,171 @@
+package mock
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/ruler"
+	"gopkg.in/yaml.v3"
+)
+
+// The ruler of a hosted instance, storing rule groups by namespace
+type rulerInstance struct {
+	namespaces map[string][]*ruler.RuleGroup
+}
+
+func newRulerInstance() *rulerInstance {
+	return &rulerInstance{
+		namespaces: make(map[string][]*ruler.RuleGroup),
+	}
+}
+
+// Returns the ruler of the hosted instance of the given kind of the stack in the request URL. If
+// there's no such stack, or the request isn't authenticated with the user ID of the hosted instance,
+// this sends an error response and returns nil.
+func (g *GrafanaCloud) rulerInstance(w http.ResponseWriter, r *http.Request, kind string) *rulerInstance {
+	stackSlug := chi.URLParam(r, "stack")
+	stack := g.stackOrganisation(stackSlug).stackList.FindBySlug(stackSlug)
+	if stack == nil {
+		sendResponse(w, &errorResponse{Message: "Not found"}, http.StatusNotFound)
+		return nil
+	}
+
+	userID := 0
+	switch kind {
+	case portal.HostedMetrics:
+		userID = stack.HmInstancePromID
+	case portal.HostedLogs:
+		userID = stack.HlInstanceID
+	}
+
+	user, password, ok := r.BasicAuth()
+	if !ok || user != strconv.Itoa(userID) || password == "" {
+		sendResponse(w, &errorResponse{Message: "authentication required"}, http.StatusUnauthorized)
+		return nil
+	}
+
+	return g.stackOrganisation(stack.Slug).rulers[kind][stack.Slug]
+}
+
+func (g *GrafanaCloud) getRulerNamespace(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		instance := g.rulerInstance(w, r, kind)
+		if instance == nil {
+			return
+		}
+
+		namespace := rulerNamespace(r)
+		groups, ok := instance.namespaces[namespace]
+		if !ok {
+			sendResponse(w, &errorResponse{Message: "no rule groups found"}, http.StatusNotFound)
+			return
+		}
+
+		sendYAML(w, map[string][]*ruler.RuleGroup{namespace: groups}, http.StatusOK)
+	}
+}
+
+func (g *GrafanaCloud) setRulerRuleGroup(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		instance := g.rulerInstance(w, r, kind)
+		if instance == nil {
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			sendError(w, err)
+			return
+		}
+
+		group := &ruler.RuleGroup{}
+		if err := yaml.Unmarshal(body, group); err != nil {
+			sendResponse(w, &errorResponse{Message: fmt.Sprintf("invalid rule group: %v", err)}, http.StatusBadRequest)
+			return
+		}
+
+		if group.Name == "" || len(group.Rules) == 0 {
+			sendResponse(w, &errorResponse{Message: "rule groups must have a name and at least one rule"}, http.StatusBadRequest)
+			return
+		}
+
+		namespace := rulerNamespace(r)
+		groups := instance.namespaces[namespace]
+		for i, existing := range groups {
+			if existing.Name == group.Name {
+				groups[i] = group
+				sendResponse(w, nil, http.StatusAccepted)
+				return
+			}
+		}
+
+		instance.namespaces[namespace] = append(groups, group)
+		sendResponse(w, nil, http.StatusAccepted)
+	}
+}
+
+func (g *GrafanaCloud) deleteRulerRuleGroup(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		instance := g.rulerInstance(w, r, kind)
+		if instance == nil {
+			return
+		}
+
+		namespace := rulerNamespace(r)
+		name, _ := url.PathUnescape(chi.URLParam(r, "group"))
+
+		groups := instance.namespaces[namespace]
+		for i, group := range groups {
+			if group.Name == name {
+				instance.namespaces[namespace] = append(groups[:i], groups[i+1:]...)
+
+				// Like Cortex, namespaces only exist as long as they contain rule groups
+				if len(instance.namespaces[namespace]) == 0 {
+					delete(instance.namespaces, namespace)
+				}
+
+				sendResponse(w, nil, http.StatusAccepted)
+				return
+			}
+		}
+
+		sendResponse(w, &errorResponse{Message: "rule group not found"}, http.StatusNotFound)
+	}
+}
+
+func (g *GrafanaCloud) deleteRulerNamespace(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		instance := g.rulerInstance(w, r, kind)
+		if instance == nil {
+			return
+		}
+
+		namespace := rulerNamespace(r)
+		if _, ok := instance.namespaces[namespace]; !ok {
+			sendResponse(w, &errorResponse{Message: "namespace not found"}, http.StatusNotFound)
+			return
+		}
+
+		delete(instance.namespaces, namespace)
+		sendResponse(w, nil, http.StatusAccepted)
+	}
+}
+
+func rulerNamespace(r *http.Request) string {
+	namespace, _ := url.PathUnescape(chi.URLParam(r, "namespace"))
+	return namespace
+}
+
+func sendYAML(w http.ResponseWriter, v interface{}, status int) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(status)
+
+	if err := yaml.NewEncoder(w).Encode(v); err != nil {
+		panic(err)
+	}
+}
@@ -6,24 +6,58 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/grafana"
+	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/alertmanager"
 	"github.com/form3tech-oss/terraform-provider-grafanacloud/internal/api/portal"
 )
 
 type GrafanaCloud struct {
+	// Organisation of the API key, which owns access policies and new stacks by default
 	organisation *organisation
-	server       *httptest.Server
-	nextID       int
+
+	// All organisations by slug, including the one of the API key
+	organisations map[string]*organisation
+
+	server *httptest.Server
+	nextID int
+
+	// Terraform runs requests in parallel, so all handlers are serialised by this lock
+	mu sync.Mutex
+
+	// Number of upcoming requests which are rate limited, and how long they're asked to wait
+	rateLimitedRequests int
+	rateLimitRetryAfter time.Duration
 }
 
 type organisation struct {
+	id            int
 	name          string
 	stackList     *portal.ListStacksOutput
 	portalAPIKeys *portal.ListAPIKeysOutput
-	stackAPIKeys  map[string]*grafana.ListAPIKeysOutput
+
+	// Grafana instances running inside the stacks by stack slug
+	grafanaInstances map[string]*grafanaInstance
+
+	// Plugins installed on the stacks by stack slug
+	stackPlugins map[string]*portal.ListStackPluginsOutput
+
+	// Rulers of the hosted instances of the stacks by kind of hosted instance and stack slug
+	rulers map[string]map[string]*rulerInstance
+
+	// Configurations of the Alertmanager instances of the stacks by stack slug, only set once uploaded
+	alertmanagerConfigs map[string]*alertmanager.Config
+
+	// Access policies and their tokens by region
+	accessPolicies     map[string]*portal.ListAccessPoliciesOutput
+	accessPolicyTokens map[string]*portal.ListAccessPolicyTokensOutput
+
+	// Number of times each stack has been polled while it's still starting up
+	stackPolls map[string]int
 }
 
 type errorResponse struct {
@@ -34,35 +68,177 @@ func (g *GrafanaCloud) Start() *GrafanaCloud {
 	r := chi.NewRouter()
 
 	r.Use(middleware.Recoverer)
+	r.Use(g.serialise)
+	r.Use(g.rateLimit)
 
 	r.Post("/api/instances", g.createStack)
+	r.Post("/api/instances/{stack}", g.updateStack)
 	r.Get("/api/orgs/{org}/instances", g.listStacks)
 	r.Delete("/api/instances/{stack}", g.deleteStack)
 
+	r.Get("/api/hosted-metrics/{id}", g.getHostedInstance(portal.HostedMetrics))
+	r.Get("/api/hosted-logs/{id}", g.getHostedInstance(portal.HostedLogs))
+	r.Get("/api/hosted-traces/{id}", g.getHostedInstance(portal.HostedTraces))
+
 	r.Post("/api/orgs/{org}/api-keys", g.createPortalAPIKey)
 	r.Get("/api/orgs/{org}/api-keys", g.listPortalAPIKeys)
 	r.Delete("/api/orgs/{org}/api-keys/{name}", g.deletePortalAPIKey)
 
+	r.Post("/api/v1/accesspolicies", g.createAccessPolicy)
+	r.Get("/api/v1/accesspolicies", g.listAccessPolicies)
+	r.Post("/api/v1/accesspolicies/{id}", g.updateAccessPolicy)
+	r.Delete("/api/v1/accesspolicies/{id}", g.deleteAccessPolicy)
+
+	r.Post("/api/v1/tokens", g.createAccessPolicyToken)
+	r.Get("/api/v1/tokens", g.listAccessPolicyTokens)
+	r.Delete("/api/v1/tokens/{id}", g.deleteAccessPolicyToken)
+
+	r.Get("/api/instances/{stack}/datasources", g.listDatasources)
+
+	r.Post("/api/instances/{stack}/plugins", g.installStackPlugin)
+	r.Get("/api/instances/{stack}/plugins", g.listStackPlugins)
+	r.Post("/api/instances/{stack}/plugins/{plugin}", g.updateStackPlugin)
+	r.Delete("/api/instances/{stack}/plugins/{plugin}", g.uninstallStackPlugin)
+
 	r.Post("/api/instances/{stack}/api/auth/keys", g.createGrafanaAPIKeyProxy)
 
+	// Like for the Grafana API, these routes mock the rulers and Alertmanager instances of stacks.
+	r.Get("/api/prometheus/{stack}/api/prom/rules/{namespace}", g.getRulerNamespace(portal.HostedMetrics))
+	r.Post("/api/prometheus/{stack}/api/prom/rules/{namespace}", g.setRulerRuleGroup(portal.HostedMetrics))
+	r.Delete("/api/prometheus/{stack}/api/prom/rules/{namespace}", g.deleteRulerNamespace(portal.HostedMetrics))
+	r.Delete("/api/prometheus/{stack}/api/prom/rules/{namespace}/{group}", g.deleteRulerRuleGroup(portal.HostedMetrics))
+	r.Get("/api/loki/{stack}/loki/api/v1/rules/{namespace}", g.getRulerNamespace(portal.HostedLogs))
+	r.Post("/api/loki/{stack}/loki/api/v1/rules/{namespace}", g.setRulerRuleGroup(portal.HostedLogs))
+	r.Delete("/api/loki/{stack}/loki/api/v1/rules/{namespace}", g.deleteRulerNamespace(portal.HostedLogs))
+	r.Delete("/api/loki/{stack}/loki/api/v1/rules/{namespace}/{group}", g.deleteRulerRuleGroup(portal.HostedLogs))
+	r.Get("/api/alertmanager/{stack}/api/v1/alerts", g.getAlertmanagerConfig)
+	r.Post("/api/alertmanager/{stack}/api/v1/alerts", g.setAlertmanagerConfig)
+	r.Delete("/api/alertmanager/{stack}/api/v1/alerts", g.deleteAlertmanagerConfig)
+
 	// Grafana Cloud API doesn't really offer routes at /api/grafana. These are just provided
 	// here so that we can mock the Grafana API running inside Grafana Cloud stacks.
 	r.Get("/api/grafana/{stack}/api/auth/keys", g.listGrafanaAPIKeys)
 	r.Delete("/api/grafana/{stack}/api/auth/keys/{id}", g.deleteGrafanaAPIKey)
 
+	r.Post("/api/grafana/{stack}/api/serviceaccounts", g.createServiceAccount)
+	r.Get("/api/grafana/{stack}/api/serviceaccounts/search", g.searchServiceAccounts)
+	r.Post("/api/grafana/{stack}/api/serviceaccounts/migrate/{id}", g.migrateGrafanaAPIKey)
+	r.Get("/api/grafana/{stack}/api/serviceaccounts/{id}", g.getServiceAccount)
+	r.Patch("/api/grafana/{stack}/api/serviceaccounts/{id}", g.updateServiceAccount)
+	r.Delete("/api/grafana/{stack}/api/serviceaccounts/{id}", g.deleteServiceAccount)
+	r.Post("/api/grafana/{stack}/api/serviceaccounts/{id}/tokens", g.createServiceAccountToken)
+	r.Get("/api/grafana/{stack}/api/serviceaccounts/{id}/tokens", g.listServiceAccountTokens)
+	r.Delete("/api/grafana/{stack}/api/serviceaccounts/{id}/tokens/{tokenID}", g.deleteServiceAccountToken)
+
+	r.Post("/api/grafana/{stack}/api/dashboards/db", g.saveDashboard)
+	r.Get("/api/grafana/{stack}/api/dashboards/uid/{uid}", g.getDashboard)
+	r.Delete("/api/grafana/{stack}/api/dashboards/uid/{uid}", g.deleteDashboard)
+
+	r.Post("/api/grafana/{stack}/api/folders", g.createFolder)
+	r.Get("/api/grafana/{stack}/api/folders/{uid}", g.getFolder)
+	r.Put("/api/grafana/{stack}/api/folders/{uid}", g.updateFolder)
+	r.Delete("/api/grafana/{stack}/api/folders/{uid}", g.deleteFolder)
+	r.Get("/api/grafana/{stack}/api/folders/{uid}/permissions", g.listFolderPermissions)
+	r.Post("/api/grafana/{stack}/api/folders/{uid}/permissions", g.updateFolderPermissions)
+
+	r.Post("/api/grafana/{stack}/api/datasources", g.createDatasource)
+	r.Get("/api/grafana/{stack}/api/datasources/uid/{uid}", g.getDatasource)
+	r.Put("/api/grafana/{stack}/api/datasources/uid/{uid}", g.updateDatasource)
+	r.Delete("/api/grafana/{stack}/api/datasources/uid/{uid}", g.deleteDatasource)
+
+	r.Get("/api/grafana/{stack}/api/v1/provisioning/folder/{uid}/rule-groups/{group}", g.getRuleGroup)
+	r.Put("/api/grafana/{stack}/api/v1/provisioning/folder/{uid}/rule-groups/{group}", g.putRuleGroup)
+	r.Delete("/api/grafana/{stack}/api/v1/provisioning/folder/{uid}/rule-groups/{group}", g.deleteRuleGroup)
+
+	r.Post("/api/grafana/{stack}/api/v1/provisioning/contact-points", g.createContactPoint)
+	r.Get("/api/grafana/{stack}/api/v1/provisioning/contact-points", g.listContactPoints)
+	r.Put("/api/grafana/{stack}/api/v1/provisioning/contact-points/{uid}", g.updateContactPoint)
+	r.Delete("/api/grafana/{stack}/api/v1/provisioning/contact-points/{uid}", g.deleteContactPoint)
+
+	r.Get("/api/grafana/{stack}/api/v1/provisioning/policies", g.getNotificationPolicy)
+	r.Put("/api/grafana/{stack}/api/v1/provisioning/policies", g.setNotificationPolicy)
+	r.Delete("/api/grafana/{stack}/api/v1/provisioning/policies", g.resetNotificationPolicy)
+
+	r.Post("/api/grafana/{stack}/api/v1/provisioning/mute-timings", g.createMuteTiming)
+	r.Get("/api/grafana/{stack}/api/v1/provisioning/mute-timings/{name}", g.getMuteTiming)
+	r.Put("/api/grafana/{stack}/api/v1/provisioning/mute-timings/{name}", g.updateMuteTiming)
+	r.Delete("/api/grafana/{stack}/api/v1/provisioning/mute-timings/{name}", g.deleteMuteTiming)
+
 	g.server = httptest.NewServer(r)
 	return g
 }
 
 func NewGrafanaCloud(org string) *GrafanaCloud {
-	return &GrafanaCloud{
-		organisation: &organisation{
-			name:          org,
-			stackList:     &portal.ListStacksOutput{},
-			portalAPIKeys: &portal.ListAPIKeysOutput{},
-			stackAPIKeys:  make(map[string]*grafana.ListAPIKeysOutput),
+	g := &GrafanaCloud{
+		organisations: make(map[string]*organisation),
+	}
+
+	g.organisation = g.addOrganisation(org)
+	return g
+}
+
+// Adds another organisation, which the API key of the organisation given to `NewGrafanaCloud` can manage as well.
+func (g *GrafanaCloud) WithOrganisation(org string) *GrafanaCloud {
+	g.addOrganisation(org)
+	return g
+}
+
+func (g *GrafanaCloud) addOrganisation(name string) *organisation {
+	org := &organisation{
+		id:            g.GetNextID(),
+		name:          name,
+		stackList:     &portal.ListStacksOutput{},
+		portalAPIKeys: &portal.ListAPIKeysOutput{},
+
+		grafanaInstances: make(map[string]*grafanaInstance),
+		stackPlugins:     make(map[string]*portal.ListStackPluginsOutput),
+		stackPolls:       make(map[string]int),
+
+		rulers: map[string]map[string]*rulerInstance{
+			portal.HostedMetrics: make(map[string]*rulerInstance),
+			portal.HostedLogs:    make(map[string]*rulerInstance),
 		},
+		alertmanagerConfigs: make(map[string]*alertmanager.Config),
+
+		accessPolicies:     make(map[string]*portal.ListAccessPoliciesOutput),
+		accessPolicyTokens: make(map[string]*portal.ListAccessPolicyTokensOutput),
 	}
+
+	g.organisations[name] = org
+	return org
+}
+
+// Returns the organisation in the request URL. If there's no such organisation, this sends a 404
+// response and returns nil.
+func (g *GrafanaCloud) requestOrganisation(w http.ResponseWriter, r *http.Request) *organisation {
+	org, ok := g.organisations[chi.URLParam(r, "org")]
+	if !ok {
+		sendResponse(w, &errorResponse{Message: "organisation not found"}, http.StatusNotFound)
+		return nil
+	}
+
+	return org
+}
+
+// Returns the organisation owning the stack, or the organisation of the API key if there's no such stack.
+func (g *GrafanaCloud) stackOrganisation(stackSlug string) *organisation {
+	for _, org := range g.organisations {
+		if org.stackList.FindBySlug(stackSlug) != nil {
+			return org
+		}
+	}
+
+	return g.organisation
+}
+
+// Returns the stacks of all organisations.
+func (g *GrafanaCloud) stacks() []*portal.Stack {
+	var stacks []*portal.Stack
+	for _, org := range g.organisations {
+		stacks = append(stacks, org.stackList.Items...)
+	}
+
+	return stacks
 }
 
 func (g *GrafanaCloud) Close() {
@@ -114,6 +290,37 @@ func sendError(w http.ResponseWriter, err error) {
 	}
 }
 
+func (g *GrafanaCloud) serialise(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Rate limits the next requests, which are asked to retry after the given duration.
+func (g *GrafanaCloud) RateLimitNext(requests int, retryAfter time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.rateLimitedRequests = requests
+	g.rateLimitRetryAfter = retryAfter
+}
+
+func (g *GrafanaCloud) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if g.rateLimitedRequests > 0 {
+			g.rateLimitedRequests--
+			w.Header().Set("Retry-After", strconv.Itoa(int(g.rateLimitRetryAfter.Seconds())))
+			sendResponse(w, &errorResponse{Message: "too many requests"}, http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (g *GrafanaCloud) GetNextID() int {
 	g.nextID += 1
 	return g.nextID
@@ -0,0 +1,198 @@
+package util
+
+import (
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Retries back off exponentially with jitter between these durations, unless the API asks for
+	// a specific duration by a `Retry-After` header
+	RetryMinWaitTime = 1 * time.Second
+	RetryMaxWaitTime = 60 * time.Second
+)
+
+// Throttles the requests of a client to an API on the client side, so Terraform's parallelism doesn't
+// run into the rate limits of the API.
+type Throttle struct {
+	name    string
+	limiter *rate.Limiter
+
+	// Slots of requests in flight, which is nil if their number isn't capped
+	slots chan struct{}
+
+	mu    sync.Mutex
+	stats ThrottleStats
+}
+
+type ThrottleStats struct {
+	Requests int
+
+	// Requests which had to wait for the rate limit or the concurrency cap, and how long they waited in total
+	Throttled int
+	Waited    time.Duration
+
+	// Responses by which the API rate limited the client anyway
+	RateLimited int
+}
+
+// Creates a throttle for the API of the given name, which allows the given number of requests per second
+// and requests in flight. Both are unlimited if not positive.
+func NewThrottle(name string, requestsPerSecond float64, maxConcurrentRequests int) *Throttle {
+	t := &Throttle{
+		name:    name,
+		limiter: rate.NewLimiter(rate.Inf, 0),
+	}
+
+	if requestsPerSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Ceil(requestsPerSecond)))
+	}
+
+	if maxConcurrentRequests > 0 {
+		t.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return t
+}
+
+// Throttles all requests of the client, including retries. Retries back off exponentially with jitter,
+// honouring the `Retry-After` header of rate limited responses. Clients the throttle is applied to share
+// its limits.
+func (t *Throttle) Apply(c *resty.Client) {
+	hc := c.GetClient()
+	hc.Transport = &throttledTransport{
+		throttle: t,
+		next:     hc.Transport,
+	}
+
+	c.SetRetryWaitTime(RetryMinWaitTime).
+		SetRetryMaxWaitTime(RetryMaxWaitTime).
+		SetRetryAfter(retryAfter)
+}
+
+func (t *Throttle) Stats() ThrottleStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.stats
+}
+
+func (t *Throttle) LogStats() {
+	stats := t.Stats()
+	log.Printf("[DEBUG] %s: %d requests, %d throttled for %s in total, %d rate limited by the API", t.name, stats.Requests, stats.Throttled, stats.Waited, stats.RateLimited)
+}
+
+func (t *Throttle) wait(req *http.Request) (func(), error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	release := func() {}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+			release = func() { <-t.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := t.limiter.Wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+
+	waited := time.Since(start)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stats.Requests++
+
+	// Waiting for a token of a non-empty bucket still takes a little while
+	if waited >= time.Millisecond {
+		t.stats.Throttled++
+		t.stats.Waited += waited
+		log.Printf("[DEBUG] %s: throttled %s to `%s` for %s, %d of %d requests throttled for %s in total", t.name, req.Method, req.URL, waited, t.stats.Throttled, t.stats.Requests, t.stats.Waited)
+	}
+
+	return release, nil
+}
+
+func (t *Throttle) recordRateLimited(req *http.Request, resp *http.Response) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stats.RateLimited++
+	log.Printf("[DEBUG] %s: %s to `%s` was rate limited by the API with Retry-After `%s`, %d of %d requests rate limited in total", t.name, req.Method, req.URL, resp.Header.Get("Retry-After"), t.stats.RateLimited, t.stats.Requests)
+}
+
+type throttledTransport struct {
+	throttle *Throttle
+	next     http.RoundTripper
+}
+
+func (tt *throttledTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := tt.throttle.wait(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tt.next.RoundTrip(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		tt.throttle.recordRateLimited(req, resp)
+	}
+
+	// The request is in flight until its body has been read, so the slot is only released once it's closed
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releasingBody struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// Whether the response is rate limited by the API, so the request should be retried.
+func IsRateLimited(r *resty.Response, err error) bool {
+	return r.StatusCode() == http.StatusTooManyRequests
+}
+
+// Waits as long as the `Retry-After` header of the response asks for, which is either a number of seconds or
+// a date. Otherwise, resty falls back to exponential backoff with jitter.
+func retryAfter(c *resty.Client, r *resty.Response) (time.Duration, error) {
+	header := r.Header().Get("Retry-After")
+	if header == "" {
+		return 0, nil
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	if date, err := http.ParseTime(header); err == nil && time.Until(date) > 0 {
+		return time.Until(date), nil
+	}
+
+	return 0, nil
+}
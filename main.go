@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/form3tech-oss/terraform-provider-grafanacloud/grafanacloud"
@@ -14,16 +16,28 @@ var (
 	commit  string = ""
 )
 
+// Terraform kills the plugin about two seconds after it stopped serving, so deleting the temporary keys is
+// best-effort. Keys which couldn't be deleted in time expire on their own.
+const shutdownTimeout = 1500 * time.Millisecond
+
 func main() {
-	var debugMode bool
+	var debugMode, sweepTempKeys, dryRun bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&sweepTempKeys, "sweep-temp-keys", false, "set to true to delete temporary Grafana API keys left behind on all stacks instead of running the provider, configured by the provider's environment variables")
+	flag.BoolVar(&dryRun, "dry-run", false, "set to true to only list the temporary Grafana API keys which -sweep-temp-keys would delete")
 	flag.Parse()
 
+	if sweepTempKeys {
+		sweep(dryRun)
+		return
+	}
+
 	opts := &plugin.ServeOpts{ProviderFunc: grafanacloud.NewProvider(version)}
 
 	if debugMode {
 		err := plugin.Debug(context.Background(), grafanacloud.Addr, opts)
+		shutdown()
 		if err != nil {
 			log.Fatal(err.Error())
 		}
@@ -31,4 +45,28 @@ func main() {
 	}
 
 	plugin.Serve(opts)
+	shutdown()
+}
+
+func shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	grafanacloud.Shutdown(ctx)
+}
+
+func sweep(dryRun bool) {
+	keys, err := grafanacloud.SweepTempKeys(context.Background(), version, dryRun)
+	for _, key := range keys {
+		action := "deleted"
+		if dryRun {
+			action = "would delete"
+		}
+
+		fmt.Printf("%s temporary key %s (ID %d, expiration %q) on stack %s\n", action, key.Name, key.ID, key.Expiration, key.Stack)
+	}
+
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }